@@ -2,32 +2,53 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"log"
 
 	"github.com/influenzanet/data-service/internal/config"
 	gc "github.com/influenzanet/data-service/pkg/grpc/clients"
 	"github.com/influenzanet/data-service/pkg/grpc/service"
+	"github.com/influenzanet/data-service/pkg/logger"
 	"github.com/influenzanet/data-service/pkg/types"
+	"go.uber.org/zap"
 )
 
 func main() {
 	clients := &types.APIClients{}
 	conf := config.InitConfig()
 
-	loggingClient, close := gc.ConnectToLoggingService(conf.ServiceURLs.LoggingService)
-	defer close()
+	l, err := logger.New(conf.Logging.Level, conf.Logging.Format)
+	if err != nil {
+		log.Fatalf("failed to create logger: %v", err)
+	}
+	defer l.Sync()
+	zap.ReplaceGlobals(l)
+
+	var serviceTLS *tls.Config
+	if conf.ServiceTLS.IsEnabled() {
+		serviceTLS, err = config.ClientTLSConfig(conf.ServiceTLS)
+		if err != nil {
+			l.Fatal("failed to load TLS config for services", zap.Error(err))
+		}
+	}
+
+	loggingClient, loggingConn := gc.ConnectToLoggingService(conf.ServiceURLs.LoggingService, conf.MessageSizes.MaxRecv, serviceTLS)
+	defer loggingConn.Close()
 	clients.LoggingService = loggingClient
+	clients.LoggingServiceConn = loggingConn
 
-	studyClient, close := gc.ConnectToStudyService(conf.ServiceURLs.StudyService)
-	defer close()
+	studyClient, studyConn := gc.ConnectToStudyService(conf.ServiceURLs.StudyService, conf.MessageSizes.MaxRecv, serviceTLS)
+	defer studyConn.Close()
 	clients.StudyService = studyClient
+	clients.StudyServiceConn = studyConn
 
 	ctx := context.Background()
 	if err := service.RunServer(
 		ctx,
-		conf.Port,
+		conf,
 		clients,
+		l,
 	); err != nil {
-		log.Fatal(err)
+		l.Fatal("server stopped", zap.Error(err))
 	}
 }
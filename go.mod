@@ -8,7 +8,11 @@ require (
 	github.com/influenzanet/go-utils v0.2.7
 	github.com/influenzanet/logging-service v0.1.0
 	github.com/influenzanet/study-service v0.14.1
+	github.com/klauspost/compress v1.12.3
+	github.com/prometheus/client_golang v1.10.0
 	go.mongodb.org/mongo-driver v1.5.2
+	go.uber.org/zap v1.16.0
 	google.golang.org/grpc v1.37.1
 	google.golang.org/protobuf v1.26.0
+	sigs.k8s.io/yaml v1.2.0
 )
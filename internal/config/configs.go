@@ -1,24 +1,272 @@
 package config
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/influenzanet/data-service/internal/constants"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/export_profiles"
+	"github.com/influenzanet/data-service/pkg/logger"
+	"sigs.k8s.io/yaml"
 )
 
 // Config is the structure that holds all global configuration data
 type Config struct {
-	Port        string
+	Port string `json:"port"`
+	// port of the HTTP endpoint for Prometheus metrics, no metrics are served if empty
+	MetricsPort string `json:"metricsPort"`
 	ServiceURLs struct {
-		LoggingService string
-		StudyService   string
+		LoggingService string `json:"loggingService"`
+		StudyService   string `json:"studyService"`
+	} `json:"serviceURLs"`
+	Logging         Logging                  `json:"logging"`
+	Timeouts        Timeouts                 `json:"timeouts"`
+	MessageSizes    MessageSizes             `json:"messageSizes"`
+	TLS             TLSConfig                `json:"tls"`
+	ServiceTLS      ServiceTLSConfig         `json:"serviceTLS"`
+	HealthCheck     HealthCheck              `json:"healthCheck"`
+	ExportLimits    ExportLimits             `json:"exportLimits"`
+	ExportAdmission ExportAdmission          `json:"exportAdmission"`
+	ExportPolicy    export_policy.Policy     `json:"exportPolicy"`
+	ExportProfiles  export_profiles.Profiles `json:"exportProfiles"`
+}
+
+// Logging configures the service logs
+type Logging struct {
+	// debug, info, warn or error, info if empty
+	Level string `json:"level"`
+	// json or console, json if empty
+	Format string `json:"format"`
+}
+
+// Timeouts of the service, zero means no timeout
+type Timeouts struct {
+	// for requests to other services that do not stream data
+	ServiceRequest Duration `json:"serviceRequest"`
+}
+
+// MessageSizes are the maximum gRPC message sizes in bytes, zero keeps the gRPC default
+type MessageSizes struct {
+	MaxRecv int `json:"maxRecv"`
+	MaxSend int `json:"maxSend"`
+}
+
+// TLSConfig holds the certificate files, TLS is used if a certificate is set
+type TLSConfig struct {
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// if set, clients have to present a certificate signed by this CA
+	ClientCAFile string `json:"clientCAFile"`
+}
+
+// ServiceTLSConfig configures TLS for the connections to the study and logging service
+type ServiceTLSConfig struct {
+	Enabled bool `json:"enabled"`
+	// CA bundle to verify the services, the system roots are used if empty
+	CAFile string `json:"caFile"`
+	// client certificate, if the services require mTLS
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+}
+
+// IsEnabled is true if TLS is enabled explicitly or a certificate file is set
+func (c ServiceTLSConfig) IsEnabled() bool {
+	return c.Enabled || c.CAFile != "" || c.CertFile != ""
+}
+
+// HealthCheck configures how often the study and logging service are probed, zero keeps the defaults
+type HealthCheck struct {
+	Interval Duration `json:"interval"`
+	Timeout  Duration `json:"timeout"`
+}
+
+// ExportLimits restrict the size of a single request, zero means no limit
+type ExportLimits struct {
+	MaxResponses int      `json:"maxResponses"`
+	MaxTimeRange Duration `json:"maxTimeRange"`
+	// longer exports are stopped with DeadlineExceeded
+	MaxDuration Duration `json:"maxDuration"`
+}
+
+// ExportAdmission limits the exports running at the same time, zero means no limit. Exports over the
+// limits wait in a queue.
+type ExportAdmission struct {
+	MaxConcurrent int `json:"maxConcurrent"`
+	MaxPerUser    int `json:"maxPerUser"`
+	MaxPerStudy   int `json:"maxPerStudy"`
+	// exports are rejected if the queue is full or they waited longer than maxQueueWait
+	MaxQueue     int      `json:"maxQueue"`
+	MaxQueueWait Duration `json:"maxQueueWait"`
+	// estimated bytes the responses of all running exports may use
+	MemoryBudget int64 `json:"memoryBudget"`
+}
+
+// Duration is read from strings like "30s" or "1h30m"
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		return errors.New("duration must be a string like \"30s\"")
 	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Duration.String())
 }
 
+// InitConfig reads the config file and the environment variables, invalid configurations stop the service
 func InitConfig() Config {
-	conf := Config{}
-	conf.Port = os.Getenv(constants.ENV_DATA_SERVICE_LISTEN_PORT)
-	conf.ServiceURLs.LoggingService = os.Getenv(constants.ENV_ADDR_LOGGING_SERVICE)
-	conf.ServiceURLs.StudyService = os.Getenv(constants.ENV_ADDR_STUDY_SERVICE)
+	conf, err := LoadConfig(os.Getenv(constants.ENV_CONFIG_FILE))
+	if err != nil {
+		log.Fatalf("invalid configuration: %v", err)
+	}
 	return conf
 }
+
+// LoadConfig reads the YAML or JSON config file, if a path is given, and applies the environment variables
+// on top of it
+func LoadConfig(path string) (Config, error) {
+	conf := Config{}
+	if path != "" {
+		if err := readFile(path, &conf); err != nil {
+			return conf, fmt.Errorf("config file: %v", err)
+		}
+	}
+
+	overrideFromEnv(&conf.Port, constants.ENV_DATA_SERVICE_LISTEN_PORT)
+	overrideFromEnv(&conf.MetricsPort, constants.ENV_METRICS_LISTEN_PORT)
+	overrideFromEnv(&conf.Logging.Level, constants.ENV_LOG_LEVEL)
+	overrideFromEnv(&conf.ServiceURLs.LoggingService, constants.ENV_ADDR_LOGGING_SERVICE)
+	overrideFromEnv(&conf.ServiceURLs.StudyService, constants.ENV_ADDR_STUDY_SERVICE)
+	overrideFromEnv(&conf.TLS.CertFile, constants.ENV_TLS_CERT_FILE)
+	overrideFromEnv(&conf.TLS.KeyFile, constants.ENV_TLS_KEY_FILE)
+	overrideFromEnv(&conf.TLS.ClientCAFile, constants.ENV_TLS_CLIENT_CA_FILE)
+	overrideFromEnv(&conf.ServiceTLS.CAFile, constants.ENV_SERVICE_TLS_CA_FILE)
+	overrideFromEnv(&conf.ServiceTLS.CertFile, constants.ENV_SERVICE_TLS_CERT_FILE)
+	overrideFromEnv(&conf.ServiceTLS.KeyFile, constants.ENV_SERVICE_TLS_KEY_FILE)
+	if p := os.Getenv(constants.ENV_EXPORT_POLICY_FILE); p != "" {
+		conf.ExportPolicy = export_policy.Policy{}
+		if err := readFile(p, &conf.ExportPolicy); err != nil {
+			return conf, fmt.Errorf("export policy: %v", err)
+		}
+	}
+	if p := os.Getenv(constants.ENV_EXPORT_PROFILES_FILE); p != "" {
+		conf.ExportProfiles = export_profiles.Profiles{}
+		if err := readFile(p, &conf.ExportProfiles); err != nil {
+			return conf, fmt.Errorf("export profiles: %v", err)
+		}
+	}
+
+	return conf, conf.Validate()
+}
+
+func overrideFromEnv(value *string, envName string) {
+	if v := os.Getenv(envName); v != "" {
+		*value = v
+	}
+}
+
+// readFile parses YAML or JSON, unknown fields are reported as errors
+func readFile(path string, v interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(content, v)
+}
+
+// Validate lists all problems of the configuration
+func (conf Config) Validate() error {
+	problems := []string{}
+	if port, err := strconv.Atoi(conf.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("port: %q is not a valid port", conf.Port))
+	}
+	if conf.MetricsPort != "" {
+		if port, err := strconv.Atoi(conf.MetricsPort); err != nil || port < 1 || port > 65535 {
+			problems = append(problems, fmt.Sprintf("metricsPort: %q is not a valid port", conf.MetricsPort))
+		} else if conf.MetricsPort == conf.Port {
+			problems = append(problems, "metricsPort: must differ from port")
+		}
+	}
+	if conf.ServiceURLs.LoggingService == "" {
+		problems = append(problems, "serviceURLs.loggingService: missing address")
+	}
+	if conf.ServiceURLs.StudyService == "" {
+		problems = append(problems, "serviceURLs.studyService: missing address")
+	}
+	if _, err := logger.ParseLevel(conf.Logging.Level); err != nil {
+		problems = append(problems, fmt.Sprintf("logging.level: %v", err))
+	}
+	if f := conf.Logging.Format; f != "" && f != "json" && f != "console" {
+		problems = append(problems, fmt.Sprintf("logging.format: unknown format %q", f))
+	}
+	if conf.Timeouts.ServiceRequest.Duration < 0 {
+		problems = append(problems, "timeouts.serviceRequest: must not be negative")
+	}
+	if conf.MessageSizes.MaxRecv < 0 || conf.MessageSizes.MaxSend < 0 {
+		problems = append(problems, "messageSizes: must not be negative")
+	}
+	if (conf.TLS.CertFile == "") != (conf.TLS.KeyFile == "") {
+		problems = append(problems, "tls: certFile and keyFile have to be set together")
+	}
+	if conf.TLS.ClientCAFile != "" && conf.TLS.CertFile == "" {
+		problems = append(problems, "tls.clientCAFile: needs certFile and keyFile")
+	}
+	if (conf.ServiceTLS.CertFile == "") != (conf.ServiceTLS.KeyFile == "") {
+		problems = append(problems, "serviceTLS: certFile and keyFile have to be set together")
+	}
+	for _, f := range []string{
+		conf.TLS.CertFile, conf.TLS.KeyFile, conf.TLS.ClientCAFile,
+		conf.ServiceTLS.CAFile, conf.ServiceTLS.CertFile, conf.ServiceTLS.KeyFile,
+	} {
+		if f == "" {
+			continue
+		}
+		if _, err := os.Stat(f); err != nil {
+			problems = append(problems, fmt.Sprintf("tls: %v", err))
+		}
+	}
+	if conf.HealthCheck.Interval.Duration < 0 || conf.HealthCheck.Timeout.Duration < 0 {
+		problems = append(problems, "healthCheck: must not be negative")
+	}
+	if conf.ExportLimits.MaxResponses < 0 || conf.ExportLimits.MaxTimeRange.Duration < 0 || conf.ExportLimits.MaxDuration.Duration < 0 {
+		problems = append(problems, "exportLimits: must not be negative")
+	}
+	if a := conf.ExportAdmission; a.MaxConcurrent < 0 || a.MaxPerUser < 0 || a.MaxPerStudy < 0 || a.MaxQueue < 0 ||
+		a.MaxQueueWait.Duration < 0 || a.MemoryBudget < 0 {
+		problems = append(problems, "exportAdmission: must not be negative")
+	}
+	if err := conf.ExportPolicy.Validate(); err != nil {
+		problems = append(problems, fmt.Sprintf("exportPolicy: %v", err))
+	}
+	if err := conf.ExportProfiles.Validate(); err != nil {
+		problems = append(problems, fmt.Sprintf("exportProfiles: %v", err))
+	}
+	for name, p := range conf.ExportProfiles {
+		if p.Pseudonymised && conf.ExportPolicy.PseudonymisationKey == "" {
+			problems = append(problems, fmt.Sprintf("exportProfiles: profile %s: pseudonymisation needs exportPolicy.pseudonymisationKey", name))
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influenzanet/data-service/internal/constants"
+)
+
+func writeTestFile(t *testing.T, name string, content string) string {
+	dir, err := ioutil.TempDir("", "data-service-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Run("yaml file", func(t *testing.T) {
+		path := writeTestFile(t, "config.yaml", `
+port: "5203"
+serviceURLs:
+  loggingService: logging:5006
+  studyService: study:5203
+timeouts:
+  serviceRequest: 30s
+exportLimits:
+  maxResponses: 1000
+exportProfiles:
+  ecdc-weekly:
+    shortQuestionKeys: true
+    separator: "-"
+    columns: ["Q1-*"]
+`)
+		conf, err := LoadConfig(path)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if conf.Timeouts.ServiceRequest.Duration != 30*time.Second || conf.ExportLimits.MaxResponses != 1000 {
+			t.Errorf("unexpected config: %v", conf)
+		}
+		if p, ok := conf.ExportProfiles["ecdc-weekly"]; !ok || !p.ShortQuestionKeys || p.Columns[0] != "Q1-*" {
+			t.Errorf("unexpected profiles: %v", conf.ExportProfiles)
+		}
+	})
+
+	t.Run("json file with env override", func(t *testing.T) {
+		path := writeTestFile(t, "config.json", `{"port": "5203", "serviceURLs": {"loggingService": "logging:5006", "studyService": "study:5203"}}`)
+		os.Setenv(constants.ENV_DATA_SERVICE_LISTEN_PORT, "6000")
+		defer os.Unsetenv(constants.ENV_DATA_SERVICE_LISTEN_PORT)
+		conf, err := LoadConfig(path)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if conf.Port != "6000" || conf.ServiceURLs.StudyService != "study:5203" {
+			t.Errorf("unexpected config: %v", conf)
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		path := writeTestFile(t, "config.yaml", "port: \"5203\"\nprot: 1\n")
+		if _, err := LoadConfig(path); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		path := writeTestFile(t, "config.yaml", `
+port: "abc"
+serviceURLs:
+  loggingService: logging:5006
+tls:
+  certFile: server.crt
+exportProfiles:
+  partner:
+    pseudonymised: true
+`)
+		_, err := LoadConfig(path)
+		if err == nil {
+			t.Error("should fail with error")
+			return
+		}
+		for _, problem := range []string{"port", "serviceURLs.studyService", "tls", "pseudonymisation"} {
+			if !strings.Contains(err.Error(), problem) {
+				t.Errorf("problem with %s not reported: %v", problem, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// certificate files are checked for changes at most once per interval
+const tlsReloadInterval = 10 * time.Second
+
+// ServerTLSConfig returns the TLS config of the server. The certificate and the client CA are reloaded when
+// one of the files changes. With a client CA the clients have to present a certificate (mTLS).
+func ServerTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	r, err := newFileReloader([]string{conf.CertFile, conf.KeyFile, conf.ClientCAFile}, func() (interface{}, error) {
+		return loadServerTLSConfig(conf)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return r.get().(*tls.Config), nil
+		},
+	}, nil
+}
+
+func loadServerTLSConfig(conf TLSConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+		NextProtos:   []string{"h2"},
+	}
+	if conf.ClientCAFile != "" {
+		pool, err := loadCertPool(conf.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.ClientCAs = pool
+		tlsConf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConf, nil
+}
+
+// ClientTLSConfig returns the TLS config for connections to other services. The client certificate is
+// reloaded when its files change, the CA bundle is read once.
+func ClientTLSConfig(conf ServiceTLSConfig) (*tls.Config, error) {
+	tlsConf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if conf.CAFile != "" {
+		pool, err := loadCertPool(conf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.RootCAs = pool
+	}
+	if conf.CertFile != "" {
+		r, err := newFileReloader([]string{conf.CertFile, conf.KeyFile}, func() (interface{}, error) {
+			cert, err := tls.LoadX509KeyPair(conf.CertFile, conf.KeyFile)
+			return &cert, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.get().(*tls.Certificate), nil
+		}
+	}
+	return tlsConf, nil
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	pem, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.New("no certificates found in " + caFile)
+	}
+	return pool, nil
+}
+
+// fileReloader keeps the result of load and calls it again if one of the files was modified. If reloading
+// fails, the previous value is kept.
+type fileReloader struct {
+	files     []string
+	load      func() (interface{}, error)
+	mu        sync.Mutex
+	value     interface{}
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+func newFileReloader(files []string, load func() (interface{}, error)) (*fileReloader, error) {
+	r := &fileReloader{load: load}
+	for _, f := range files {
+		if f != "" {
+			r.files = append(r.files, f)
+		}
+	}
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.value = value
+	r.modTime = r.latestModTime()
+	r.lastCheck = time.Now()
+	return r, nil
+}
+
+func (r *fileReloader) get() interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.lastCheck) < tlsReloadInterval {
+		return r.value
+	}
+	r.lastCheck = time.Now()
+	modTime := r.latestModTime()
+	if !modTime.After(r.modTime) {
+		return r.value
+	}
+	value, err := r.load()
+	if err != nil {
+		zap.L().Error("failed to reload TLS files", zap.Strings("files", r.files), zap.Error(err))
+		return r.value
+	}
+	zap.L().Info("reloaded TLS files", zap.Strings("files", r.files))
+	r.value = value
+	r.modTime = modTime
+	return r.value
+}
+
+func (r *fileReloader) latestModTime() time.Time {
+	latest := time.Time{}
+	for _, f := range r.files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestCert creates a self-signed certificate that can also be used as CA
+func writeTestCert(t *testing.T, dir string, name string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	if err := ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func testTempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "data-service-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func handshake(serverConf *tls.Config, clientConf *tls.Config) error {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer lis.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			errs <- err
+			return
+		}
+		defer conn.Close()
+		errs <- tls.Server(conn, serverConf).Handshake()
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientConf)
+	if err != nil {
+		<-errs
+		return err
+	}
+	defer conn.Close()
+	// with TLS 1.3 a rejected client certificate is only reported by the server
+	return <-errs
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	dir := testTempDir(t)
+	serverCert, serverKey := writeTestCert(t, dir, "server")
+	clientCert, clientKey := writeTestCert(t, dir, "client")
+
+	serverConf, err := ServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: serverKey, ClientCAFile: clientCert})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	t.Run("mTLS with client certificate", func(t *testing.T) {
+		clientConf, err := ClientTLSConfig(ServiceTLSConfig{CAFile: serverCert, CertFile: clientCert, KeyFile: clientKey})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		clientConf.ServerName = "localhost"
+		if err := handshake(serverConf, clientConf); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("mTLS without client certificate", func(t *testing.T) {
+		clientConf, err := ClientTLSConfig(ServiceTLSConfig{CAFile: serverCert})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		clientConf.ServerName = "localhost"
+		if err := handshake(serverConf, clientConf); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		if _, err := ServerTLSConfig(TLSConfig{CertFile: filepath.Join(dir, "missing.crt"), KeyFile: serverKey}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+}
+
+func TestFileReloader(t *testing.T) {
+	dir := testTempDir(t)
+	certFile, keyFile := writeTestCert(t, dir, "server")
+
+	loads := 0
+	r, err := newFileReloader([]string{certFile, keyFile, ""}, func() (interface{}, error) {
+		loads++
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		return &cert, err
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	first := r.get().(*tls.Certificate)
+
+	t.Run("unchanged files", func(t *testing.T) {
+		r.lastCheck = time.Time{}
+		if r.get() != first || loads != 1 {
+			t.Errorf("certificate should not be reloaded, loads: %d", loads)
+		}
+	})
+
+	t.Run("changed files", func(t *testing.T) {
+		writeTestCert(t, dir, "server")
+		later := time.Now().Add(time.Minute)
+		os.Chtimes(certFile, later, later)
+
+		if r.get() != first {
+			t.Error("files should not be checked within the reload interval")
+		}
+		r.lastCheck = time.Time{}
+		if r.get() == first || loads != 2 {
+			t.Errorf("certificate should be reloaded, loads: %d", loads)
+		}
+	})
+
+	t.Run("invalid files keep the certificate", func(t *testing.T) {
+		current := r.get()
+		ioutil.WriteFile(keyFile, []byte("broken"), 0600)
+		later := time.Now().Add(2 * time.Minute)
+		os.Chtimes(keyFile, later, later)
+		r.lastCheck = time.Time{}
+		if r.get() != current {
+			t.Error("previous certificate should be kept")
+		}
+	})
+}
@@ -1,7 +1,18 @@
 package constants
 
 const (
+	ENV_CONFIG_FILE              = "DATA_SERVICE_CONFIG_FILE"
 	ENV_DATA_SERVICE_LISTEN_PORT = "DATA_SERVICE_LISTEN_PORT"
+	ENV_METRICS_LISTEN_PORT      = "DATA_SERVICE_METRICS_LISTEN_PORT"
+	ENV_LOG_LEVEL                = "DATA_SERVICE_LOG_LEVEL"
 	ENV_ADDR_STUDY_SERVICE       = "ADDR_STUDY_SERVICE"
 	ENV_ADDR_LOGGING_SERVICE     = "ADDR_LOGGING_SERVICE"
+	ENV_EXPORT_POLICY_FILE       = "DATA_SERVICE_EXPORT_POLICY_FILE"
+	ENV_EXPORT_PROFILES_FILE     = "DATA_SERVICE_EXPORT_PROFILES_FILE"
+	ENV_TLS_CERT_FILE            = "DATA_SERVICE_TLS_CERT_FILE"
+	ENV_TLS_KEY_FILE             = "DATA_SERVICE_TLS_KEY_FILE"
+	ENV_TLS_CLIENT_CA_FILE       = "DATA_SERVICE_TLS_CLIENT_CA_FILE"
+	ENV_SERVICE_TLS_CA_FILE      = "DATA_SERVICE_SERVICE_TLS_CA_FILE"
+	ENV_SERVICE_TLS_CERT_FILE    = "DATA_SERVICE_SERVICE_TLS_CERT_FILE"
+	ENV_SERVICE_TLS_KEY_FILE     = "DATA_SERVICE_SERVICE_TLS_KEY_FILE"
 )
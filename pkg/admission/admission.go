@@ -0,0 +1,223 @@
+// Package admission limits the number of concurrent exports and the memory they use. Requests over the
+// limits wait in a queue, a request is admitted as soon as its user and study are below their limits, so
+// a single user or study cannot block the others.
+package admission
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrQueueFull      = errors.New("too many exports waiting")
+	ErrMemoryExceeded = errors.New("memory budget for exports exceeded")
+)
+
+// Limits of the controller, zero means no limit
+type Limits struct {
+	MaxConcurrent int
+	MaxPerUser    int
+	MaxPerStudy   int
+	// number of waiting requests, requests are rejected if the queue is full
+	MaxQueue int
+	// bytes that all running exports may reserve together
+	MemoryBudget int64
+}
+
+// Controller admits requests within the limits
+type Controller struct {
+	limits Limits
+
+	mu       sync.Mutex
+	running  int
+	perUser  map[string]int
+	perStudy map[string]int
+	memory   int64
+	queue    []*waiter
+	// called with the number of waiting requests and the reserved memory when they change
+	onChange func(queued int, memory int64)
+}
+
+type waiter struct {
+	user      string
+	study     string
+	admitted  chan struct{}
+	positions chan int
+	position  int
+	ticket    *Ticket
+}
+
+// Ticket is held by an admitted request, Release has to be called when the request ends
+type Ticket struct {
+	c        *Controller
+	user     string
+	study    string
+	memory   int64
+	released bool
+}
+
+// NewController creates a controller, onChange may be nil
+func NewController(limits Limits, onChange func(queued int, memory int64)) *Controller {
+	return &Controller{
+		limits:   limits,
+		perUser:  map[string]int{},
+		perStudy: map[string]int{},
+		onChange: onChange,
+	}
+}
+
+// Acquire waits until the request of the user for the study is admitted or ctx is done. onPosition is
+// called with the position in the queue (starting at 1) whenever it changes, it may be nil.
+func (c *Controller) Acquire(ctx context.Context, user string, study string, onPosition func(int)) (*Ticket, error) {
+	c.mu.Lock()
+	// waiting requests are blocked by their user or study limits if the global limits allow a request
+	if c.canAdmit(user, study) {
+		t := c.admit(user, study)
+		c.mu.Unlock()
+		return t, nil
+	}
+	if c.limits.MaxQueue > 0 && len(c.queue) >= c.limits.MaxQueue {
+		c.mu.Unlock()
+		return nil, ErrQueueFull
+	}
+	w := &waiter{
+		user:      user,
+		study:     study,
+		admitted:  make(chan struct{}),
+		positions: make(chan int, 1),
+	}
+	c.queue = append(c.queue, w)
+	c.dispatch()
+	c.mu.Unlock()
+
+	for {
+		select {
+		case <-w.admitted:
+			return w.ticket, nil
+		case p := <-w.positions:
+			if onPosition != nil {
+				onPosition(p)
+			}
+		case <-ctx.Done():
+			c.mu.Lock()
+			if w.ticket != nil {
+				// admitted at the same time
+				c.mu.Unlock()
+				w.ticket.Release()
+				return nil, ctx.Err()
+			}
+			c.remove(w)
+			c.dispatch()
+			c.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Reserve adds memory used by the request, it fails if the budget of all requests is exceeded
+func (t *Ticket) Reserve(bytes int64) error {
+	c := t.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.limits.MemoryBudget > 0 && c.memory+bytes > c.limits.MemoryBudget {
+		return ErrMemoryExceeded
+	}
+	c.memory += bytes
+	t.memory += bytes
+	c.changed()
+	return nil
+}
+
+// Release frees the slot and the reserved memory and admits waiting requests, it can be called repeatedly
+func (t *Ticket) Release() {
+	c := t.c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.released {
+		return
+	}
+	t.released = true
+	c.running--
+	c.perUser[t.user]--
+	if c.perUser[t.user] == 0 {
+		delete(c.perUser, t.user)
+	}
+	c.perStudy[t.study]--
+	if c.perStudy[t.study] == 0 {
+		delete(c.perStudy, t.study)
+	}
+	c.memory -= t.memory
+	c.dispatch()
+}
+
+// canAdmit checks the limits, new requests wait while the memory budget is used up
+func (c *Controller) canAdmit(user string, study string) bool {
+	l := c.limits
+	if l.MaxConcurrent > 0 && c.running >= l.MaxConcurrent {
+		return false
+	}
+	if l.MaxPerUser > 0 && c.perUser[user] >= l.MaxPerUser {
+		return false
+	}
+	if l.MaxPerStudy > 0 && c.perStudy[study] >= l.MaxPerStudy {
+		return false
+	}
+	if l.MemoryBudget > 0 && c.memory >= l.MemoryBudget {
+		return false
+	}
+	return true
+}
+
+func (c *Controller) admit(user string, study string) *Ticket {
+	c.running++
+	c.perUser[user]++
+	c.perStudy[study]++
+	return &Ticket{c: c, user: user, study: study}
+}
+
+// dispatch admits waiting requests in order, skipping requests whose user or study is at its limit,
+// and reports the new positions. c.mu has to be held.
+func (c *Controller) dispatch() {
+	remaining := c.queue[:0]
+	for _, w := range c.queue {
+		if c.canAdmit(w.user, w.study) {
+			w.ticket = c.admit(w.user, w.study)
+			close(w.admitted)
+			continue
+		}
+		remaining = append(remaining, w)
+	}
+	for i := len(remaining); i < len(c.queue); i++ {
+		c.queue[i] = nil
+	}
+	c.queue = remaining
+	for i, w := range c.queue {
+		if w.position == i+1 {
+			continue
+		}
+		w.position = i + 1
+		// only the latest position is of interest
+		select {
+		case <-w.positions:
+		default:
+		}
+		w.positions <- w.position
+	}
+	c.changed()
+}
+
+func (c *Controller) remove(w *waiter) {
+	for i, q := range c.queue {
+		if q == w {
+			c.queue = append(c.queue[:i], c.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+func (c *Controller) changed() {
+	if c.onChange != nil {
+		c.onChange(len(c.queue), c.memory)
+	}
+}
@@ -0,0 +1,153 @@
+package admission
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// acquireAsync starts waiting for a ticket, the positions are reported on the returned channel
+func acquireAsync(c *Controller, ctx context.Context, user string, study string) (chan *Ticket, chan int) {
+	tickets := make(chan *Ticket, 1)
+	positions := make(chan int, 10)
+	go func() {
+		t, err := c.Acquire(ctx, user, study, func(p int) { positions <- p })
+		if err != nil {
+			close(tickets)
+			return
+		}
+		tickets <- t
+	}()
+	return tickets, positions
+}
+
+func expectPosition(t *testing.T, positions chan int, expected int) {
+	t.Helper()
+	select {
+	case p := <-positions:
+		if p != expected {
+			t.Errorf("unexpected position: %d", p)
+		}
+	case <-time.After(time.Second):
+		t.Errorf("position %d not reported", expected)
+	}
+}
+
+func expectTicket(t *testing.T, tickets chan *Ticket) *Ticket {
+	t.Helper()
+	select {
+	case ticket, ok := <-tickets:
+		if !ok {
+			t.Fatal("request failed")
+		}
+		return ticket
+	case <-time.After(time.Second):
+		t.Fatal("request not admitted")
+	}
+	return nil
+}
+
+func TestController(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("global limit with queue", func(t *testing.T) {
+		c := NewController(Limits{MaxConcurrent: 1}, nil)
+		first, err := c.Acquire(ctx, "u1", "s1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tickets2, positions2 := acquireAsync(c, ctx, "u2", "s1")
+		expectPosition(t, positions2, 1)
+		tickets3, positions3 := acquireAsync(c, ctx, "u3", "s1")
+		expectPosition(t, positions3, 2)
+
+		first.Release()
+		second := expectTicket(t, tickets2)
+		expectPosition(t, positions3, 1)
+		second.Release()
+		expectTicket(t, tickets3).Release()
+	})
+
+	t.Run("user at limit does not block others", func(t *testing.T) {
+		c := NewController(Limits{MaxConcurrent: 2, MaxPerUser: 1}, nil)
+		first, err := c.Acquire(ctx, "u1", "s1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tickets, positions := acquireAsync(c, ctx, "u1", "s2")
+		expectPosition(t, positions, 1)
+		other, err := c.Acquire(ctx, "u2", "s2", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		other.Release()
+		first.Release()
+		expectTicket(t, tickets).Release()
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		c := NewController(Limits{MaxPerStudy: 1, MaxQueue: 1}, nil)
+		first, err := c.Acquire(ctx, "u1", "s1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer first.Release()
+		waitCtx, cancel := context.WithCancel(ctx)
+		_, positions := acquireAsync(c, waitCtx, "u2", "s1")
+		expectPosition(t, positions, 1)
+		if _, err := c.Acquire(ctx, "u3", "s1", nil); err != ErrQueueFull {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cancel()
+	})
+
+	t.Run("cancel while waiting", func(t *testing.T) {
+		c := NewController(Limits{MaxConcurrent: 1}, nil)
+		first, err := c.Acquire(ctx, "u1", "s1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		waitCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		if _, err := c.Acquire(waitCtx, "u2", "s1", nil); err != context.DeadlineExceeded {
+			t.Errorf("unexpected error: %v", err)
+		}
+		first.Release()
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if len(c.queue) != 0 || c.running != 0 {
+			t.Errorf("unexpected state: %d queued, %d running", len(c.queue), c.running)
+		}
+	})
+
+	t.Run("memory budget", func(t *testing.T) {
+		c := NewController(Limits{MemoryBudget: 100}, nil)
+		first, err := c.Acquire(ctx, "u1", "s1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := first.Reserve(60); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		second, err := c.Acquire(ctx, "u2", "s2", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := second.Reserve(50); err != ErrMemoryExceeded {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := second.Reserve(40); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		tickets, positions := acquireAsync(c, ctx, "u3", "s3")
+		expectPosition(t, positions, 1)
+		first.Release()
+		expectTicket(t, tickets).Release()
+		second.Release()
+		second.Release()
+		if c.memory != 0 || c.running != 0 {
+			t.Errorf("unexpected state: %d bytes, %d running", c.memory, c.running)
+		}
+	})
+}
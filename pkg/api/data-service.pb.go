@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.22.0-devel
+// 	protoc-gen-go v1.26.0
 // 	protoc        v3.11.4
 // source: data_service/data-service.proto
 
@@ -8,14 +8,13 @@ package api
 
 import (
 	context "context"
-	proto "github.com/golang/protobuf/proto"
-	empty "github.com/golang/protobuf/ptypes/empty"
 	api_types "github.com/influenzanet/go-utils/pkg/api_types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -27,10 +26,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// This is a compile-time assertion that a sufficiently up-to-date version
-// of the legacy proto package is being used.
-const _ = proto.ProtoPackageIsVersion4
-
 type ResponseQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -44,6 +39,36 @@ type ResponseQuery struct {
 	IncludeMeta       bool                  `protobuf:"varint,6,opt,name=include_meta,json=includeMeta,proto3" json:"include_meta,omitempty"`
 	ShortQuestionKeys bool                  `protobuf:"varint,7,opt,name=short_question_keys,json=shortQuestionKeys,proto3" json:"short_question_keys,omitempty"`
 	Separator         string                `protobuf:"bytes,8,opt,name=separator,proto3" json:"separator,omitempty"`
+	// adds a boolean column for each case definition
+	CaseDefinitions []*CaseDefinition `protobuf:"bytes,9,rep,name=case_definitions,json=caseDefinitions,proto3" json:"case_definitions,omitempty"`
+	// computed after the case definitions, in the given order
+	DerivedColumns []*DerivedColumn `protobuf:"bytes,10,rep,name=derived_columns,json=derivedColumns,proto3" json:"derived_columns,omitempty"`
+	// adds latency, answer change and unanswered columns computed from the item meta
+	IncludeTiming bool `protobuf:"varint,11,opt,name=include_timing,json=includeTiming,proto3" json:"include_timing,omitempty"`
+	// submissions of the same participant within this window are reported as duplicates
+	DuplicateWindowMinutes int32 `protobuf:"varint,12,opt,name=duplicate_window_minutes,json=duplicateWindowMinutes,proto3" json:"duplicate_window_minutes,omitempty"`
+	// format of the data-quality report: "json" (default) or "csv"
+	ReportFormat string `protobuf:"bytes,13,opt,name=report_format,json=reportFormat,proto3" json:"report_format,omitempty"`
+	// "keepFirst", "keepLast" or "keepAll" (adds a duplicate_of column), duplicates are detected
+	// with duplicate_window_minutes, by default no deduplication is applied
+	DedupPolicy string `protobuf:"bytes,14,opt,name=dedup_policy,json=dedupPolicy,proto3" json:"dedup_policy,omitempty"`
+	// codes written instead of empty response columns, no coding is applied if not set
+	MissingValues *MissingValueCoding `protobuf:"bytes,15,opt,name=missing_values,json=missingValues,proto3" json:"missing_values,omitempty"`
+	// remaps responses of older survey versions to the layout of the current version
+	ColumnMappings  []*ColumnMapping `protobuf:"bytes,16,rep,name=column_mappings,json=columnMappings,proto3" json:"column_mappings,omitempty"`
+	TimestampFormat *TimestampFormat `protobuf:"bytes,17,opt,name=timestamp_format,json=timestampFormat,proto3" json:"timestamp_format,omitempty"`
+	// values of boolean columns, default is "TRUE" and "FALSE"
+	BooleanEncoding *BooleanEncoding `protobuf:"bytes,18,opt,name=boolean_encoding,json=booleanEncoding,proto3" json:"boolean_encoding,omitempty"`
+	// encoding of lists like the meta timestamps, default is separated by ";"
+	ArrayEncoding *ArrayEncoding `protobuf:"bytes,19,opt,name=array_encoding,json=arrayEncoding,proto3" json:"array_encoding,omitempty"`
+	CsvDialect    *CSVDialect    `protobuf:"bytes,20,opt,name=csv_dialect,json=csvDialect,proto3" json:"csv_dialect,omitempty"`
+	// compression of the chunk stream: "gzip" or "zstd", by default the data is not compressed
+	Compression string `protobuf:"bytes,21,opt,name=compression,proto3" json:"compression,omitempty"`
+	// name of an export profile of the service configuration, used by GetResponsesCSV. The format and column
+	// options of the profile replace the ones of the request.
+	Profile string `protobuf:"bytes,22,opt,name=profile,proto3" json:"profile,omitempty"`
+	// GetResponsesCSV sends the data-quality report in report_format after the export
+	IncludeQualityReport bool `protobuf:"varint,23,opt,name=include_quality_report,json=includeQualityReport,proto3" json:"include_quality_report,omitempty"`
 }
 
 func (x *ResponseQuery) Reset() {
@@ -134,6 +159,111 @@ func (x *ResponseQuery) GetSeparator() string {
 	return ""
 }
 
+func (x *ResponseQuery) GetCaseDefinitions() []*CaseDefinition {
+	if x != nil {
+		return x.CaseDefinitions
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetDerivedColumns() []*DerivedColumn {
+	if x != nil {
+		return x.DerivedColumns
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetIncludeTiming() bool {
+	if x != nil {
+		return x.IncludeTiming
+	}
+	return false
+}
+
+func (x *ResponseQuery) GetDuplicateWindowMinutes() int32 {
+	if x != nil {
+		return x.DuplicateWindowMinutes
+	}
+	return 0
+}
+
+func (x *ResponseQuery) GetReportFormat() string {
+	if x != nil {
+		return x.ReportFormat
+	}
+	return ""
+}
+
+func (x *ResponseQuery) GetDedupPolicy() string {
+	if x != nil {
+		return x.DedupPolicy
+	}
+	return ""
+}
+
+func (x *ResponseQuery) GetMissingValues() *MissingValueCoding {
+	if x != nil {
+		return x.MissingValues
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetColumnMappings() []*ColumnMapping {
+	if x != nil {
+		return x.ColumnMappings
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetTimestampFormat() *TimestampFormat {
+	if x != nil {
+		return x.TimestampFormat
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetBooleanEncoding() *BooleanEncoding {
+	if x != nil {
+		return x.BooleanEncoding
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetArrayEncoding() *ArrayEncoding {
+	if x != nil {
+		return x.ArrayEncoding
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetCsvDialect() *CSVDialect {
+	if x != nil {
+		return x.CsvDialect
+	}
+	return nil
+}
+
+func (x *ResponseQuery) GetCompression() string {
+	if x != nil {
+		return x.Compression
+	}
+	return ""
+}
+
+func (x *ResponseQuery) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ResponseQuery) GetIncludeQualityReport() bool {
+	if x != nil {
+		return x.IncludeQualityReport
+	}
+	return false
+}
+
 type SurveyInfoQuery struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -144,6 +274,7 @@ type SurveyInfoQuery struct {
 	SurveyKey         string                `protobuf:"bytes,3,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
 	PreviewLanguage   string                `protobuf:"bytes,4,opt,name=preview_language,json=previewLanguage,proto3" json:"preview_language,omitempty"`
 	ShortQuestionKeys bool                  `protobuf:"varint,5,opt,name=short_question_keys,json=shortQuestionKeys,proto3" json:"short_question_keys,omitempty"`
+	CsvDialect        *CSVDialect           `protobuf:"bytes,6,opt,name=csv_dialect,json=csvDialect,proto3" json:"csv_dialect,omitempty"`
 }
 
 func (x *SurveyInfoQuery) Reset() {
@@ -213,12 +344,23 @@ func (x *SurveyInfoQuery) GetShortQuestionKeys() bool {
 	return false
 }
 
+func (x *SurveyInfoQuery) GetCsvDialect() *CSVDialect {
+	if x != nil {
+		return x.CsvDialect
+	}
+	return nil
+}
+
 type Chunk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// position in the export queue while the request waits, chunks with a position carry no data
+	QueuePosition int32 `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	// part of the data-quality report sent after the export, compressed like the export
+	QualityReport []byte `protobuf:"bytes,3,opt,name=quality_report,json=qualityReport,proto3" json:"quality_report,omitempty"`
 }
 
 func (x *Chunk) Reset() {
@@ -260,6 +402,20 @@ func (x *Chunk) GetChunk() []byte {
 	return nil
 }
 
+func (x *Chunk) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *Chunk) GetQualityReport() []byte {
+	if x != nil {
+		return x.QualityReport
+	}
+	return nil
+}
+
 type SurveyInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -395,6 +551,9 @@ type SurveyQuestion struct {
 	Title        string         `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
 	QuestionType string         `protobuf:"bytes,3,opt,name=question_type,json=questionType,proto3" json:"question_type,omitempty"`
 	Responses    []*ResponseDef `protobuf:"bytes,4,rep,name=responses,proto3" json:"responses,omitempty"`
+	// display condition including the conditions of parent groups, e.g. "Q1 = 2 OR Q1 = 3"
+	Condition   string   `protobuf:"bytes,5,opt,name=condition,proto3" json:"condition,omitempty"`
+	Validations []string `protobuf:"bytes,6,rep,name=validations,proto3" json:"validations,omitempty"`
 }
 
 func (x *SurveyQuestion) Reset() {
@@ -457,6 +616,20 @@ func (x *SurveyQuestion) GetResponses() []*ResponseDef {
 	return nil
 }
 
+func (x *SurveyQuestion) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *SurveyQuestion) GetValidations() []string {
+	if x != nil {
+		return x.Validations
+	}
+	return nil
+}
+
 type ResponseDef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -591,257 +764,2856 @@ func (x *ResponseOption) GetLabel() string {
 	return ""
 }
 
-var File_data_service_data_service_proto protoreflect.FileDescriptor
+type ResponseStatisticsQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_data_service_data_service_proto_rawDesc = []byte{
-	0x0a, 0x1f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x64,
-	0x61, 0x74, 0x61, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x12, 0x19, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x13, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x16, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69,
-	0x6e, 0x66, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9d, 0x02, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
-	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
-	0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a,
-	0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66,
-	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
-	0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
-	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63,
-	0x6c, 0x75, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72,
-	0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x70, 0x61,
-	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x70,
-	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x22, 0xdf, 0x01, 0x0a, 0x0f, 0x53, 0x75, 0x72, 0x76, 0x65,
-	0x79, 0x49, 0x6e, 0x66, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65,
-	0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d,
-	0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x29, 0x0a,
-	0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77,
-	0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72,
-	0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x1d, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e,
-	0x6b, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x22, 0x6b, 0x0a, 0x0a, 0x53, 0x75, 0x72, 0x76, 0x65,
-	0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x4b, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x14, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a,
-	0x0a, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e,
-	0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0b, 0x75, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x09,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
-	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76,
-	0x65, 0x79, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa3, 0x01, 0x0a, 0x0e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79,
-	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f,
-	0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x44, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
-	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66,
-	0x52, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0xa1, 0x01, 0x0a, 0x0b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x25, 0x0a,
-	0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54,
-	0x79, 0x70, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x07, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e,
-	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
-	0x59, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79,
-	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x32, 0xff, 0x02, 0x0a, 0x0e, 0x44,
-	0x61, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x70, 0x69, 0x12, 0x44, 0x0a,
-	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x12, 0x5f, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x73, 0x43, 0x53, 0x56, 0x12, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
-	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79,
-	0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
-	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x30, 0x01, 0x12, 0x62, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x75, 0x72, 0x76, 0x65,
-	0x79, 0x49, 0x6e, 0x66, 0x6f, 0x43, 0x53, 0x56, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75,
-	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x51,
-	0x75, 0x65, 0x72, 0x79, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
-	0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x62, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53,
-	0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
-	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
-	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x42, 0x2e, 0x5a, 0x2c,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6c, 0x75,
-	0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	Token             *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	StudyKey          string                `protobuf:"bytes,2,opt,name=study_key,json=studyKey,proto3" json:"study_key,omitempty"`
+	SurveyKey         string                `protobuf:"bytes,3,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	From              int64                 `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	Until             int64                 `protobuf:"varint,5,opt,name=until,proto3" json:"until,omitempty"`
+	ShortQuestionKeys bool                  `protobuf:"varint,6,opt,name=short_question_keys,json=shortQuestionKeys,proto3" json:"short_question_keys,omitempty"`
+	Separator         string                `protobuf:"bytes,7,opt,name=separator,proto3" json:"separator,omitempty"`
+	// if not empty, only responses of these participants are counted
+	ParticipantIds []string `protobuf:"bytes,8,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
+	// probabilities between 0 and 1, defaults to quartiles if empty
+	Quantiles []float64 `protobuf:"fixed64,9,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`
 }
 
-var (
-	file_data_service_data_service_proto_rawDescOnce sync.Once
-	file_data_service_data_service_proto_rawDescData = file_data_service_data_service_proto_rawDesc
-)
+func (x *ResponseStatisticsQuery) Reset() {
+	*x = ResponseStatisticsQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_data_service_data_service_proto_rawDescGZIP() []byte {
-	file_data_service_data_service_proto_rawDescOnce.Do(func() {
-		file_data_service_data_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_data_service_data_service_proto_rawDescData)
-	})
-	return file_data_service_data_service_proto_rawDescData
+func (x *ResponseStatisticsQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_data_service_data_service_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_data_service_data_service_proto_goTypes = []interface{}{
-	(*ResponseQuery)(nil),           // 0: influenzanet.data_service.ResponseQuery
-	(*SurveyInfoQuery)(nil),         // 1: influenzanet.data_service.SurveyInfoQuery
-	(*Chunk)(nil),                   // 2: influenzanet.data_service.Chunk
-	(*SurveyInfo)(nil),              // 3: influenzanet.data_service.SurveyInfo
-	(*SurveyVersionPreview)(nil),    // 4: influenzanet.data_service.SurveyVersionPreview
-	(*SurveyQuestion)(nil),          // 5: influenzanet.data_service.SurveyQuestion
-	(*ResponseDef)(nil),             // 6: influenzanet.data_service.ResponseDef
-	(*ResponseOption)(nil),          // 7: influenzanet.data_service.ResponseOption
-	(*api_types.TokenInfos)(nil),    // 8: influenzanet.shared.TokenInfos
-	(*empty.Empty)(nil),             // 9: google.protobuf.Empty
-	(*api_types.ServiceStatus)(nil), // 10: influenzanet.shared.ServiceStatus
+func (*ResponseStatisticsQuery) ProtoMessage() {}
+
+func (x *ResponseStatisticsQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_data_service_data_service_proto_depIdxs = []int32{
-	8,  // 0: influenzanet.data_service.ResponseQuery.token:type_name -> influenzanet.shared.TokenInfos
-	8,  // 1: influenzanet.data_service.SurveyInfoQuery.token:type_name -> influenzanet.shared.TokenInfos
-	4,  // 2: influenzanet.data_service.SurveyInfo.versions:type_name -> influenzanet.data_service.SurveyVersionPreview
-	5,  // 3: influenzanet.data_service.SurveyVersionPreview.questions:type_name -> influenzanet.data_service.SurveyQuestion
-	6,  // 4: influenzanet.data_service.SurveyQuestion.responses:type_name -> influenzanet.data_service.ResponseDef
-	7,  // 5: influenzanet.data_service.ResponseDef.options:type_name -> influenzanet.data_service.ResponseOption
-	9,  // 6: influenzanet.data_service.DataServiceApi.Status:input_type -> google.protobuf.Empty
-	0,  // 7: influenzanet.data_service.DataServiceApi.GetResponsesCSV:input_type -> influenzanet.data_service.ResponseQuery
-	1,  // 8: influenzanet.data_service.DataServiceApi.GetSurveyInfoCSV:input_type -> influenzanet.data_service.SurveyInfoQuery
-	1,  // 9: influenzanet.data_service.DataServiceApi.GetSurveyInfo:input_type -> influenzanet.data_service.SurveyInfoQuery
-	10, // 10: influenzanet.data_service.DataServiceApi.Status:output_type -> influenzanet.shared.ServiceStatus
-	2,  // 11: influenzanet.data_service.DataServiceApi.GetResponsesCSV:output_type -> influenzanet.data_service.Chunk
-	2,  // 12: influenzanet.data_service.DataServiceApi.GetSurveyInfoCSV:output_type -> influenzanet.data_service.Chunk
-	3,  // 13: influenzanet.data_service.DataServiceApi.GetSurveyInfo:output_type -> influenzanet.data_service.SurveyInfo
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+
+// Deprecated: Use ResponseStatisticsQuery.ProtoReflect.Descriptor instead.
+func (*ResponseStatisticsQuery) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{8}
 }
 
-func init() { file_data_service_data_service_proto_init() }
-func file_data_service_data_service_proto_init() {
-	if File_data_service_data_service_proto != nil {
-		return
+func (x *ResponseStatisticsQuery) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_data_service_data_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseQuery); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SurveyInfoQuery); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Chunk); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SurveyInfo); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SurveyVersionPreview); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SurveyQuestion); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_data_service_data_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseDef); i {
-			case 0:
-				return &v.state
+	return nil
+}
+
+func (x *ResponseStatisticsQuery) GetStudyKey() string {
+	if x != nil {
+		return x.StudyKey
+	}
+	return ""
+}
+
+func (x *ResponseStatisticsQuery) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ResponseStatisticsQuery) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ResponseStatisticsQuery) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ResponseStatisticsQuery) GetShortQuestionKeys() bool {
+	if x != nil {
+		return x.ShortQuestionKeys
+	}
+	return false
+}
+
+func (x *ResponseStatisticsQuery) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *ResponseStatisticsQuery) GetParticipantIds() []string {
+	if x != nil {
+		return x.ParticipantIds
+	}
+	return nil
+}
+
+func (x *ResponseStatisticsQuery) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type ResponseStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyKey string                `protobuf:"bytes,1,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	Questions []*QuestionStatistics `protobuf:"bytes,2,rep,name=questions,proto3" json:"questions,omitempty"`
+}
+
+func (x *ResponseStatistics) Reset() {
+	*x = ResponseStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseStatistics) ProtoMessage() {}
+
+func (x *ResponseStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseStatistics.ProtoReflect.Descriptor instead.
+func (*ResponseStatistics) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ResponseStatistics) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ResponseStatistics) GetQuestions() []*QuestionStatistics {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type QuestionStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VersionId    string `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	QuestionKey  string `protobuf:"bytes,2,opt,name=question_key,json=questionKey,proto3" json:"question_key,omitempty"`
+	QuestionType string `protobuf:"bytes,3,opt,name=question_type,json=questionType,proto3" json:"question_type,omitempty"`
+	// number of responses submitted for this survey version
+	ResponseCount int64                     `protobuf:"varint,4,opt,name=response_count,json=responseCount,proto3" json:"response_count,omitempty"`
+	Slots         []*ResponseSlotStatistics `protobuf:"bytes,5,rep,name=slots,proto3" json:"slots,omitempty"`
+}
+
+func (x *QuestionStatistics) Reset() {
+	*x = QuestionStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuestionStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuestionStatistics) ProtoMessage() {}
+
+func (x *QuestionStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuestionStatistics.ProtoReflect.Descriptor instead.
+func (*QuestionStatistics) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QuestionStatistics) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *QuestionStatistics) GetQuestionKey() string {
+	if x != nil {
+		return x.QuestionKey
+	}
+	return ""
+}
+
+func (x *QuestionStatistics) GetQuestionType() string {
+	if x != nil {
+		return x.QuestionType
+	}
+	return ""
+}
+
+func (x *QuestionStatistics) GetResponseCount() int64 {
+	if x != nil {
+		return x.ResponseCount
+	}
+	return 0
+}
+
+func (x *QuestionStatistics) GetSlots() []*ResponseSlotStatistics {
+	if x != nil {
+		return x.Slots
+	}
+	return nil
+}
+
+type ResponseSlotStatistics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// column name as used in the response export
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// number of responses with a value for this slot
+	Answered     int64            `protobuf:"varint,2,opt,name=answered,proto3" json:"answered,omitempty"`
+	OptionCounts map[string]int64 `protobuf:"bytes,3,rep,name=option_counts,json=optionCounts,proto3" json:"option_counts,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	Numeric      *NumericSummary  `protobuf:"bytes,4,opt,name=numeric,proto3" json:"numeric,omitempty"`
+}
+
+func (x *ResponseSlotStatistics) Reset() {
+	*x = ResponseSlotStatistics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseSlotStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseSlotStatistics) ProtoMessage() {}
+
+func (x *ResponseSlotStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseSlotStatistics.ProtoReflect.Descriptor instead.
+func (*ResponseSlotStatistics) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ResponseSlotStatistics) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ResponseSlotStatistics) GetAnswered() int64 {
+	if x != nil {
+		return x.Answered
+	}
+	return 0
+}
+
+func (x *ResponseSlotStatistics) GetOptionCounts() map[string]int64 {
+	if x != nil {
+		return x.OptionCounts
+	}
+	return nil
+}
+
+func (x *ResponseSlotStatistics) GetNumeric() *NumericSummary {
+	if x != nil {
+		return x.Numeric
+	}
+	return nil
+}
+
+type NumericSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Count     int64       `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Min       float64     `protobuf:"fixed64,2,opt,name=min,proto3" json:"min,omitempty"`
+	Max       float64     `protobuf:"fixed64,3,opt,name=max,proto3" json:"max,omitempty"`
+	Mean      float64     `protobuf:"fixed64,4,opt,name=mean,proto3" json:"mean,omitempty"`
+	Quantiles []*Quantile `protobuf:"bytes,5,rep,name=quantiles,proto3" json:"quantiles,omitempty"`
+}
+
+func (x *NumericSummary) Reset() {
+	*x = NumericSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NumericSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumericSummary) ProtoMessage() {}
+
+func (x *NumericSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumericSummary.ProtoReflect.Descriptor instead.
+func (*NumericSummary) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *NumericSummary) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NumericSummary) GetMin() float64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *NumericSummary) GetMax() float64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+func (x *NumericSummary) GetMean() float64 {
+	if x != nil {
+		return x.Mean
+	}
+	return 0
+}
+
+func (x *NumericSummary) GetQuantiles() []*Quantile {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type Quantile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Probability float64 `protobuf:"fixed64,1,opt,name=probability,proto3" json:"probability,omitempty"`
+	Value       float64 `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *Quantile) Reset() {
+	*x = Quantile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Quantile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quantile) ProtoMessage() {}
+
+func (x *Quantile) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quantile.ProtoReflect.Descriptor instead.
+func (*Quantile) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Quantile) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+func (x *Quantile) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type ResponseTimeSeriesQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token             *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	StudyKey          string                `protobuf:"bytes,2,opt,name=study_key,json=studyKey,proto3" json:"study_key,omitempty"`
+	SurveyKey         string                `protobuf:"bytes,3,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	From              int64                 `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	Until             int64                 `protobuf:"varint,5,opt,name=until,proto3" json:"until,omitempty"`
+	ShortQuestionKeys bool                  `protobuf:"varint,6,opt,name=short_question_keys,json=shortQuestionKeys,proto3" json:"short_question_keys,omitempty"`
+	Separator         string                `protobuf:"bytes,7,opt,name=separator,proto3" json:"separator,omitempty"`
+	ParticipantIds    []string              `protobuf:"bytes,8,rep,name=participant_ids,json=participantIds,proto3" json:"participant_ids,omitempty"`
+	// "day", "week" (ISO week, default) or "month"
+	Bucket string `protobuf:"bytes,9,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	// IANA timezone name used to compute bucket boundaries, defaults to UTC
+	Timezone string `protobuf:"bytes,10,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// if empty, all questions are included
+	QuestionKeys []string  `protobuf:"bytes,11,rep,name=question_keys,json=questionKeys,proto3" json:"question_keys,omitempty"`
+	Quantiles    []float64 `protobuf:"fixed64,12,rep,packed,name=quantiles,proto3" json:"quantiles,omitempty"`
+}
+
+func (x *ResponseTimeSeriesQuery) Reset() {
+	*x = ResponseTimeSeriesQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseTimeSeriesQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseTimeSeriesQuery) ProtoMessage() {}
+
+func (x *ResponseTimeSeriesQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseTimeSeriesQuery.ProtoReflect.Descriptor instead.
+func (*ResponseTimeSeriesQuery) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResponseTimeSeriesQuery) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ResponseTimeSeriesQuery) GetStudyKey() string {
+	if x != nil {
+		return x.StudyKey
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeriesQuery) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeriesQuery) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ResponseTimeSeriesQuery) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ResponseTimeSeriesQuery) GetShortQuestionKeys() bool {
+	if x != nil {
+		return x.ShortQuestionKeys
+	}
+	return false
+}
+
+func (x *ResponseTimeSeriesQuery) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeriesQuery) GetParticipantIds() []string {
+	if x != nil {
+		return x.ParticipantIds
+	}
+	return nil
+}
+
+func (x *ResponseTimeSeriesQuery) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeriesQuery) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeriesQuery) GetQuestionKeys() []string {
+	if x != nil {
+		return x.QuestionKeys
+	}
+	return nil
+}
+
+func (x *ResponseTimeSeriesQuery) GetQuantiles() []float64 {
+	if x != nil {
+		return x.Quantiles
+	}
+	return nil
+}
+
+type ResponseTimeSeries struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyKey string              `protobuf:"bytes,1,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	Bucket    string              `protobuf:"bytes,2,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Timezone  string              `protobuf:"bytes,3,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Buckets   []*TimeSeriesBucket `protobuf:"bytes,4,rep,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *ResponseTimeSeries) Reset() {
+	*x = ResponseTimeSeries{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseTimeSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseTimeSeries) ProtoMessage() {}
+
+func (x *ResponseTimeSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseTimeSeries.ProtoReflect.Descriptor instead.
+func (*ResponseTimeSeries) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResponseTimeSeries) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeries) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeries) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ResponseTimeSeries) GetBuckets() []*TimeSeriesBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type TimeSeriesBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// e.g. "2021-W05", "2021-02-01" or "2021-02"
+	Label         string                `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Start         int64                 `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           int64                 `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	ResponseCount int64                 `protobuf:"varint,4,opt,name=response_count,json=responseCount,proto3" json:"response_count,omitempty"`
+	Questions     []*QuestionStatistics `protobuf:"bytes,5,rep,name=questions,proto3" json:"questions,omitempty"`
+}
+
+func (x *TimeSeriesBucket) Reset() {
+	*x = TimeSeriesBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeSeriesBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeSeriesBucket) ProtoMessage() {}
+
+func (x *TimeSeriesBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeSeriesBucket.ProtoReflect.Descriptor instead.
+func (*TimeSeriesBucket) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TimeSeriesBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *TimeSeriesBucket) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *TimeSeriesBucket) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *TimeSeriesBucket) GetResponseCount() int64 {
+	if x != nil {
+		return x.ResponseCount
+	}
+	return 0
+}
+
+func (x *TimeSeriesBucket) GetQuestions() []*QuestionStatistics {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+type CaseDefinition struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rule *CaseRule `protobuf:"bytes,2,opt,name=rule,proto3" json:"rule,omitempty"`
+}
+
+func (x *CaseDefinition) Reset() {
+	*x = CaseDefinition{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaseDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseDefinition) ProtoMessage() {}
+
+func (x *CaseDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseDefinition.ProtoReflect.Descriptor instead.
+func (*CaseDefinition) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CaseDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CaseDefinition) GetRule() *CaseRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type CaseRule struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "and", "or", "not", "hasValue", "equals" or "isTrue"
+	Operator string `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	// response column the operator is applied to
+	Column string   `protobuf:"bytes,2,opt,name=column,proto3" json:"column,omitempty"`
+	Values []string `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	// sub-rules for "and", "or" and "not"
+	Rules []*CaseRule `protobuf:"bytes,4,rep,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *CaseRule) Reset() {
+	*x = CaseRule{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaseRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseRule) ProtoMessage() {}
+
+func (x *CaseRule) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseRule.ProtoReflect.Descriptor instead.
+func (*CaseRule) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CaseRule) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *CaseRule) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *CaseRule) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *CaseRule) GetRules() []*CaseRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// e.g. in version "3" option "b" of "Q1" was renamed to "c" (option_key = "b", new_keys = ["c"]),
+// or "Q5" was split into "Q5a" and "Q5b" (option_key empty, new_keys = ["Q5a", "Q5b"])
+type ColumnMapping struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VersionId   string   `protobuf:"bytes,1,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"`
+	QuestionKey string   `protobuf:"bytes,2,opt,name=question_key,json=questionKey,proto3" json:"question_key,omitempty"`
+	OptionKey   string   `protobuf:"bytes,3,opt,name=option_key,json=optionKey,proto3" json:"option_key,omitempty"`
+	NewKeys     []string `protobuf:"bytes,4,rep,name=new_keys,json=newKeys,proto3" json:"new_keys,omitempty"`
+}
+
+func (x *ColumnMapping) Reset() {
+	*x = ColumnMapping{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ColumnMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColumnMapping) ProtoMessage() {}
+
+func (x *ColumnMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColumnMapping.ProtoReflect.Descriptor instead.
+func (*ColumnMapping) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ColumnMapping) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetQuestionKey() string {
+	if x != nil {
+		return x.QuestionKey
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetOptionKey() string {
+	if x != nil {
+		return x.OptionKey
+	}
+	return ""
+}
+
+func (x *ColumnMapping) GetNewKeys() []string {
+	if x != nil {
+		return x.NewKeys
+	}
+	return nil
+}
+
+type TimestampFormat struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// "unix" (default) or "iso8601"
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// IANA name, e.g. "Europe/Berlin", default is "UTC"
+	Timezone string `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// replaces the submitted column with a date and a time column
+	SeparateDateTime bool `protobuf:"varint,3,opt,name=separate_date_time,json=separateDateTime,proto3" json:"separate_date_time,omitempty"`
+	// writes answers of date inputs as YYYY-MM-DD
+	DateInputsAsDate bool `protobuf:"varint,4,opt,name=date_inputs_as_date,json=dateInputsAsDate,proto3" json:"date_inputs_as_date,omitempty"`
+}
+
+func (x *TimestampFormat) Reset() {
+	*x = TimestampFormat{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimestampFormat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimestampFormat) ProtoMessage() {}
+
+func (x *TimestampFormat) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimestampFormat.ProtoReflect.Descriptor instead.
+func (*TimestampFormat) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TimestampFormat) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *TimestampFormat) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *TimestampFormat) GetSeparateDateTime() bool {
+	if x != nil {
+		return x.SeparateDateTime
+	}
+	return false
+}
+
+func (x *TimestampFormat) GetDateInputsAsDate() bool {
+	if x != nil {
+		return x.DateInputsAsDate
+	}
+	return false
+}
+
+type CSVDialect struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// single character, default is ","
+	Delimiter string `protobuf:"bytes,1,opt,name=delimiter,proto3" json:"delimiter,omitempty"`
+	QuoteAll  bool   `protobuf:"varint,2,opt,name=quote_all,json=quoteAll,proto3" json:"quote_all,omitempty"`
+	// starts the file with a UTF-8 byte order mark
+	Bom  bool `protobuf:"varint,3,opt,name=bom,proto3" json:"bom,omitempty"`
+	Crlf bool `protobuf:"varint,4,opt,name=crlf,proto3" json:"crlf,omitempty"`
+	// written for empty cells, default is an empty string
+	NullValue string `protobuf:"bytes,5,opt,name=null_value,json=nullValue,proto3" json:"null_value,omitempty"`
+}
+
+func (x *CSVDialect) Reset() {
+	*x = CSVDialect{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CSVDialect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CSVDialect) ProtoMessage() {}
+
+func (x *CSVDialect) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CSVDialect.ProtoReflect.Descriptor instead.
+func (*CSVDialect) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CSVDialect) GetDelimiter() string {
+	if x != nil {
+		return x.Delimiter
+	}
+	return ""
+}
+
+func (x *CSVDialect) GetQuoteAll() bool {
+	if x != nil {
+		return x.QuoteAll
+	}
+	return false
+}
+
+func (x *CSVDialect) GetBom() bool {
+	if x != nil {
+		return x.Bom
+	}
+	return false
+}
+
+func (x *CSVDialect) GetCrlf() bool {
+	if x != nil {
+		return x.Crlf
+	}
+	return false
+}
+
+func (x *CSVDialect) GetNullValue() string {
+	if x != nil {
+		return x.NullValue
+	}
+	return ""
+}
+
+type BooleanEncoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TrueValue  string `protobuf:"bytes,1,opt,name=true_value,json=trueValue,proto3" json:"true_value,omitempty"`
+	FalseValue string `protobuf:"bytes,2,opt,name=false_value,json=falseValue,proto3" json:"false_value,omitempty"`
+}
+
+func (x *BooleanEncoding) Reset() {
+	*x = BooleanEncoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BooleanEncoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BooleanEncoding) ProtoMessage() {}
+
+func (x *BooleanEncoding) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BooleanEncoding.ProtoReflect.Descriptor instead.
+func (*BooleanEncoding) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BooleanEncoding) GetTrueValue() string {
+	if x != nil {
+		return x.TrueValue
+	}
+	return ""
+}
+
+func (x *BooleanEncoding) GetFalseValue() string {
+	if x != nil {
+		return x.FalseValue
+	}
+	return ""
+}
+
+type ArrayEncoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Separator string `protobuf:"bytes,1,opt,name=separator,proto3" json:"separator,omitempty"`
+	// writes lists as JSON arrays, the separator is ignored
+	Json bool `protobuf:"varint,2,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (x *ArrayEncoding) Reset() {
+	*x = ArrayEncoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArrayEncoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArrayEncoding) ProtoMessage() {}
+
+func (x *ArrayEncoding) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArrayEncoding.ProtoReflect.Descriptor instead.
+func (*ArrayEncoding) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ArrayEncoding) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *ArrayEncoding) GetJson() bool {
+	if x != nil {
+		return x.Json
+	}
+	return false
+}
+
+type MissingValueCoding struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// question is not part of the survey version of the response
+	NotInVersion string `protobuf:"bytes,1,opt,name=not_in_version,json=notInVersion,proto3" json:"not_in_version,omitempty"`
+	// question was never displayed, e.g. because its condition was false
+	NotShown string `protobuf:"bytes,2,opt,name=not_shown,json=notShown,proto3" json:"not_shown,omitempty"`
+	// question was displayed but not answered
+	Skipped string `protobuf:"bytes,3,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// question was answered but the column has no value, e.g. no selection in a multiple choice group
+	AnsweredEmpty string `protobuf:"bytes,4,opt,name=answered_empty,json=answeredEmpty,proto3" json:"answered_empty,omitempty"`
+}
+
+func (x *MissingValueCoding) Reset() {
+	*x = MissingValueCoding{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MissingValueCoding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissingValueCoding) ProtoMessage() {}
+
+func (x *MissingValueCoding) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissingValueCoding.ProtoReflect.Descriptor instead.
+func (*MissingValueCoding) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MissingValueCoding) GetNotInVersion() string {
+	if x != nil {
+		return x.NotInVersion
+	}
+	return ""
+}
+
+func (x *MissingValueCoding) GetNotShown() string {
+	if x != nil {
+		return x.NotShown
+	}
+	return ""
+}
+
+func (x *MissingValueCoding) GetSkipped() string {
+	if x != nil {
+		return x.Skipped
+	}
+	return ""
+}
+
+func (x *MissingValueCoding) GetAnsweredEmpty() string {
+	if x != nil {
+		return x.AnsweredEmpty
+	}
+	return ""
+}
+
+type DerivedColumn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// e.g. "year(submitted) - Q2" or "Q1.a OR Q1.b"
+	Expression string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+}
+
+func (x *DerivedColumn) Reset() {
+	*x = DerivedColumn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DerivedColumn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DerivedColumn) ProtoMessage() {}
+
+func (x *DerivedColumn) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DerivedColumn.ProtoReflect.Descriptor instead.
+func (*DerivedColumn) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DerivedColumn) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DerivedColumn) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+type IncidenceQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token             *api_types.TokenInfos `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	StudyKey          string                `protobuf:"bytes,2,opt,name=study_key,json=studyKey,proto3" json:"study_key,omitempty"`
+	SurveyKey         string                `protobuf:"bytes,3,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	From              int64                 `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	Until             int64                 `protobuf:"varint,5,opt,name=until,proto3" json:"until,omitempty"`
+	ShortQuestionKeys bool                  `protobuf:"varint,6,opt,name=short_question_keys,json=shortQuestionKeys,proto3" json:"short_question_keys,omitempty"`
+	Separator         string                `protobuf:"bytes,7,opt,name=separator,proto3" json:"separator,omitempty"`
+	CaseDefinitions   []*CaseDefinition     `protobuf:"bytes,8,rep,name=case_definitions,json=caseDefinitions,proto3" json:"case_definitions,omitempty"`
+	Timezone          string                `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	// applied to both cases and active participants
+	Rules *ActiveParticipantRules `protobuf:"bytes,10,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *IncidenceQuery) Reset() {
+	*x = IncidenceQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncidenceQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidenceQuery) ProtoMessage() {}
+
+func (x *IncidenceQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidenceQuery.ProtoReflect.Descriptor instead.
+func (*IncidenceQuery) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *IncidenceQuery) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *IncidenceQuery) GetStudyKey() string {
+	if x != nil {
+		return x.StudyKey
+	}
+	return ""
+}
+
+func (x *IncidenceQuery) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *IncidenceQuery) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *IncidenceQuery) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *IncidenceQuery) GetShortQuestionKeys() bool {
+	if x != nil {
+		return x.ShortQuestionKeys
+	}
+	return false
+}
+
+func (x *IncidenceQuery) GetSeparator() string {
+	if x != nil {
+		return x.Separator
+	}
+	return ""
+}
+
+func (x *IncidenceQuery) GetCaseDefinitions() []*CaseDefinition {
+	if x != nil {
+		return x.CaseDefinitions
+	}
+	return nil
+}
+
+func (x *IncidenceQuery) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *IncidenceQuery) GetRules() *ActiveParticipantRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type Incidence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyKey string             `protobuf:"bytes,1,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	Timezone  string             `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Weeks     []*IncidenceBucket `protobuf:"bytes,3,rep,name=weeks,proto3" json:"weeks,omitempty"`
+}
+
+func (x *Incidence) Reset() {
+	*x = Incidence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Incidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incidence) ProtoMessage() {}
+
+func (x *Incidence) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incidence.ProtoReflect.Descriptor instead.
+func (*Incidence) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Incidence) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *Incidence) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Incidence) GetWeeks() []*IncidenceBucket {
+	if x != nil {
+		return x.Weeks
+	}
+	return nil
+}
+
+type IncidenceBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label              string       `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Start              int64        `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                int64        `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	ActiveParticipants int64        `protobuf:"varint,4,opt,name=active_participants,json=activeParticipants,proto3" json:"active_participants,omitempty"`
+	Cases              []*CaseCount `protobuf:"bytes,5,rep,name=cases,proto3" json:"cases,omitempty"`
+}
+
+func (x *IncidenceBucket) Reset() {
+	*x = IncidenceBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IncidenceBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidenceBucket) ProtoMessage() {}
+
+func (x *IncidenceBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidenceBucket.ProtoReflect.Descriptor instead.
+func (*IncidenceBucket) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *IncidenceBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *IncidenceBucket) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *IncidenceBucket) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *IncidenceBucket) GetActiveParticipants() int64 {
+	if x != nil {
+		return x.ActiveParticipants
+	}
+	return 0
+}
+
+func (x *IncidenceBucket) GetCases() []*CaseCount {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+type CaseCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// cases per 1000 active participants
+	Incidence float64 `protobuf:"fixed64,3,opt,name=incidence,proto3" json:"incidence,omitempty"`
+}
+
+func (x *CaseCount) Reset() {
+	*x = CaseCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CaseCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaseCount) ProtoMessage() {}
+
+func (x *CaseCount) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaseCount.ProtoReflect.Descriptor instead.
+func (*CaseCount) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CaseCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CaseCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CaseCount) GetIncidence() float64 {
+	if x != nil {
+		return x.Incidence
+	}
+	return 0
+}
+
+type ActiveParticipantRules struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// participants with fewer reports in the queried time range are not counted
+	MinReports int32 `protobuf:"varint,1,opt,name=min_reports,json=minReports,proto3" json:"min_reports,omitempty"`
+	// skip the first report of each participant in the queried time range
+	ExcludeFirstReport bool `protobuf:"varint,2,opt,name=exclude_first_report,json=excludeFirstReport,proto3" json:"exclude_first_report,omitempty"`
+}
+
+func (x *ActiveParticipantRules) Reset() {
+	*x = ActiveParticipantRules{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveParticipantRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveParticipantRules) ProtoMessage() {}
+
+func (x *ActiveParticipantRules) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveParticipantRules.ProtoReflect.Descriptor instead.
+func (*ActiveParticipantRules) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ActiveParticipantRules) GetMinReports() int32 {
+	if x != nil {
+		return x.MinReports
+	}
+	return 0
+}
+
+func (x *ActiveParticipantRules) GetExcludeFirstReport() bool {
+	if x != nil {
+		return x.ExcludeFirstReport
+	}
+	return false
+}
+
+type ActiveParticipantsQuery struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token     *api_types.TokenInfos   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	StudyKey  string                  `protobuf:"bytes,2,opt,name=study_key,json=studyKey,proto3" json:"study_key,omitempty"`
+	SurveyKey string                  `protobuf:"bytes,3,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	From      int64                   `protobuf:"varint,4,opt,name=from,proto3" json:"from,omitempty"`
+	Until     int64                   `protobuf:"varint,5,opt,name=until,proto3" json:"until,omitempty"`
+	Timezone  string                  `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Rules     *ActiveParticipantRules `protobuf:"bytes,7,opt,name=rules,proto3" json:"rules,omitempty"`
+}
+
+func (x *ActiveParticipantsQuery) Reset() {
+	*x = ActiveParticipantsQuery{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveParticipantsQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveParticipantsQuery) ProtoMessage() {}
+
+func (x *ActiveParticipantsQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveParticipantsQuery.ProtoReflect.Descriptor instead.
+func (*ActiveParticipantsQuery) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ActiveParticipantsQuery) GetToken() *api_types.TokenInfos {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+func (x *ActiveParticipantsQuery) GetStudyKey() string {
+	if x != nil {
+		return x.StudyKey
+	}
+	return ""
+}
+
+func (x *ActiveParticipantsQuery) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ActiveParticipantsQuery) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *ActiveParticipantsQuery) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *ActiveParticipantsQuery) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ActiveParticipantsQuery) GetRules() *ActiveParticipantRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+type ActiveParticipants struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SurveyKey string                      `protobuf:"bytes,1,opt,name=survey_key,json=surveyKey,proto3" json:"survey_key,omitempty"`
+	Timezone  string                      `protobuf:"bytes,2,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Weeks     []*ActiveParticipantsBucket `protobuf:"bytes,3,rep,name=weeks,proto3" json:"weeks,omitempty"`
+}
+
+func (x *ActiveParticipants) Reset() {
+	*x = ActiveParticipants{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveParticipants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveParticipants) ProtoMessage() {}
+
+func (x *ActiveParticipants) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveParticipants.ProtoReflect.Descriptor instead.
+func (*ActiveParticipants) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ActiveParticipants) GetSurveyKey() string {
+	if x != nil {
+		return x.SurveyKey
+	}
+	return ""
+}
+
+func (x *ActiveParticipants) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ActiveParticipants) GetWeeks() []*ActiveParticipantsBucket {
+	if x != nil {
+		return x.Weeks
+	}
+	return nil
+}
+
+type ActiveParticipantsBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Label              string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Start              int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End                int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	ActiveParticipants int64  `protobuf:"varint,4,opt,name=active_participants,json=activeParticipants,proto3" json:"active_participants,omitempty"`
+	Reports            int64  `protobuf:"varint,5,opt,name=reports,proto3" json:"reports,omitempty"`
+}
+
+func (x *ActiveParticipantsBucket) Reset() {
+	*x = ActiveParticipantsBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_data_service_data_service_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActiveParticipantsBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActiveParticipantsBucket) ProtoMessage() {}
+
+func (x *ActiveParticipantsBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_data_service_data_service_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActiveParticipantsBucket.ProtoReflect.Descriptor instead.
+func (*ActiveParticipantsBucket) Descriptor() ([]byte, []int) {
+	return file_data_service_data_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ActiveParticipantsBucket) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ActiveParticipantsBucket) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *ActiveParticipantsBucket) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *ActiveParticipantsBucket) GetActiveParticipants() int64 {
+	if x != nil {
+		return x.ActiveParticipants
+	}
+	return 0
+}
+
+func (x *ActiveParticipantsBucket) GetReports() int64 {
+	if x != nil {
+		return x.Reports
+	}
+	return 0
+}
+
+var File_data_service_data_service_proto protoreflect.FileDescriptor
+
+var file_data_service_data_service_proto_rawDesc = []byte{
+	0x0a, 0x1f, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x64,
+	0x61, 0x74, 0x61, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x19, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x1a, 0x13, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x16, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x5f, 0x69,
+	0x6e, 0x66, 0x6f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70, 0x74, 0x79,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd1, 0x09, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12,
+	0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x5f, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72,
+	0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x65, 0x70, 0x61,
+	0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x70,
+	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x54, 0x0a, 0x10, 0x63, 0x61, 0x73, 0x65, 0x5f, 0x64,
+	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x61, 0x73,
+	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0f, 0x63, 0x61, 0x73,
+	0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x51, 0x0a, 0x0f,
+	0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x5f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x44, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x52,
+	0x0e, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65,
+	0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x38, 0x0a, 0x18, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x5f, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x6d, 0x69, 0x6e, 0x75, 0x74,
+	0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x16, 0x64, 0x75, 0x70, 0x6c, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d, 0x69, 0x6e, 0x75, 0x74, 0x65, 0x73,
+	0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x64, 0x75, 0x70, 0x5f, 0x70,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x64,
+	0x75, 0x70, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x54, 0x0a, 0x0e, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x4d, 0x69, 0x73,
+	0x73, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52,
+	0x0d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x51,
+	0x0a, 0x0f, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x5f, 0x6d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e,
+	0x67, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67,
+	0x73, 0x12, 0x55, 0x0a, 0x10, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x55, 0x0a, 0x10, 0x62, 0x6f, 0x6f, 0x6c,
+	0x65, 0x61, 0x6e, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x12, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x0f,
+	0x62, 0x6f, 0x6f, 0x6c, 0x65, 0x61, 0x6e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12,
+	0x4f, 0x0a, 0x0e, 0x61, 0x72, 0x72, 0x61, 0x79, 0x5f, 0x65, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
+	0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x41, 0x72, 0x72, 0x61, 0x79, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x0d, 0x61, 0x72, 0x72, 0x61, 0x79, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67,
+	0x12, 0x46, 0x0a, 0x0b, 0x63, 0x73, 0x76, 0x5f, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x43, 0x53, 0x56, 0x44, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x0a, 0x63, 0x73,
+	0x76, 0x44, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x34, 0x0a, 0x16, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f,
+	0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x17,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x51, 0x75, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0xa7, 0x02, 0x0a, 0x0f, 0x53,
+	0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x35,
+	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64, 0x79, 0x4b,
+	0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x70, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x13,
+	0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b,
+	0x65, 0x79, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72, 0x74,
+	0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x46, 0x0a, 0x0b,
+	0x63, 0x73, 0x76, 0x5f, 0x64, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x25, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74,
+	0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x53,
+	0x56, 0x44, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x52, 0x0a, 0x63, 0x73, 0x76, 0x44, 0x69, 0x61,
+	0x6c, 0x65, 0x63, 0x74, 0x22, 0x6b, 0x0a, 0x05, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75, 0x65, 0x75, 0x65, 0x5f, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x71, 0x75, 0x65,
+	0x75, 0x65, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x71, 0x75,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x22, 0x6b, 0x0a, 0x0a, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x4b, 0x0a, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x65,
+	0x76, 0x69, 0x65, 0x77, 0x52, 0x08, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xbe,
+	0x01, 0x0a, 0x14, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x72, 0x65, 0x76, 0x69, 0x65, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x73, 0x68, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x75, 0x6e, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x75, 0x6e, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x47, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x51, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22,
+	0xe3, 0x01, 0x0a, 0x0e, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x44, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x26, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x66, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xa1, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x44, 0x65, 0x66, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x12, 0x43, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a,
+	0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x59, 0x0a, 0x0e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1f, 0x0a,
+	0x0b, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x22, 0xcb, 0x02, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x2e, 0x0a,
+	0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72,
+	0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x01, 0x52, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c,
+	0x65, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72,
+	0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x4b, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xeb, 0x01, 0x0a, 0x12, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x23,
+	0x0a, 0x0d, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x47, 0x0a, 0x05, 0x73, 0x6c,
+	0x6f, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6c,
+	0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x05, 0x73, 0x6c,
+	0x6f, 0x74, 0x73, 0x22, 0xb6, 0x02, 0x0a, 0x16, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x1a, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12, 0x68, 0x0a, 0x0d,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x43, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x6c, 0x6f, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x43, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69,
+	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x4e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d, 0x6d, 0x61,
+	0x72, 0x79, 0x52, 0x07, 0x6e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x1a, 0x3f, 0x0a, 0x11, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa1, 0x01, 0x0a,
+	0x0e, 0x4e, 0x75, 0x6d, 0x65, 0x72, 0x69, 0x63, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x65, 0x61,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6d, 0x65, 0x61, 0x6e, 0x12, 0x41, 0x0a,
+	0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x51, 0x75, 0x61,
+	0x6e, 0x74, 0x69, 0x6c, 0x65, 0x52, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73,
+	0x22, 0x42, 0x0a, 0x08, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x22, 0xa4, 0x03, 0x0a, 0x17, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79,
+	0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73,
+	0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b,
+	0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x2e, 0x0a,
+	0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f, 0x72,
+	0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e,
+	0x74, 0x49, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0c, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x01,
+	0x52, 0x09, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x6c, 0x65, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x12,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b, 0x65,
+	0x79, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x45, 0x0a, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x22, 0xc4, 0x01, 0x0a,
+	0x10, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x4b, 0x0a, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x52, 0x09, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x5d, 0x0a, 0x0e, 0x43, 0x61, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x72, 0x75, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x43, 0x61, 0x73, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x04, 0x72, 0x75,
+	0x6c, 0x65, 0x22, 0x91, 0x01, 0x0a, 0x08, 0x43, 0x61, 0x73, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6c,
+	0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x05, 0x72,
+	0x75, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x69, 0x6e, 0x66,
+	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x61, 0x73, 0x65, 0x52, 0x75, 0x6c, 0x65, 0x52,
+	0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x4d, 0x61, 0x70, 0x70, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x6e, 0x65, 0x77,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x77,
+	0x4b, 0x65, 0x79, 0x73, 0x22, 0xa2, 0x01, 0x0a, 0x0f, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d,
+	0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x2c, 0x0a, 0x12,
+	0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61,
+	0x74, 0x65, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x13, 0x64, 0x61,
+	0x74, 0x65, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x5f, 0x61, 0x73, 0x5f, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x61, 0x74, 0x65, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x41, 0x73, 0x44, 0x61, 0x74, 0x65, 0x22, 0x8c, 0x01, 0x0a, 0x0a, 0x43, 0x53,
+	0x56, 0x44, 0x69, 0x61, 0x6c, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x65, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f,
+	0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x41, 0x6c, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x03, 0x62, 0x6f, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x72, 0x6c, 0x66, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x63, 0x72, 0x6c, 0x66, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x75, 0x6c,
+	0x6c, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e,
+	0x75, 0x6c, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x51, 0x0a, 0x0f, 0x42, 0x6f, 0x6f, 0x6c,
+	0x65, 0x61, 0x6e, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1d, 0x0a, 0x0a, 0x74,
+	0x72, 0x75, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x74, 0x72, 0x75, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x61,
+	0x6c, 0x73, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x66, 0x61, 0x6c, 0x73, 0x65, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x41, 0x0a, 0x0d, 0x41,
+	0x72, 0x72, 0x61, 0x79, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09,
+	0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x73,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6a, 0x73, 0x6f, 0x6e, 0x22, 0x98,
+	0x01, 0x0a, 0x12, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x43,
+	0x6f, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x6f, 0x74, 0x5f, 0x69, 0x6e, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e,
+	0x6f, 0x74, 0x49, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x6f, 0x74, 0x5f, 0x73, 0x68, 0x6f, 0x77, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6e, 0x6f, 0x74, 0x53, 0x68, 0x6f, 0x77, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70,
+	0x70, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70,
+	0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x65,
+	0x6d, 0x70, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x65, 0x64, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x43, 0x0a, 0x0d, 0x44, 0x65, 0x72,
+	0x69, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e,
+	0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xb6,
+	0x03, 0x0a, 0x0e, 0x49, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1f, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x73, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64,
+	0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75,
+	0x64, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69,
+	0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x2e,
+	0x0a, 0x13, 0x73, 0x68, 0x6f, 0x72, 0x74, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x73, 0x68, 0x6f,
+	0x72, 0x74, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x54, 0x0a, 0x10,
+	0x63, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e,
+	0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x43, 0x61, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x0f, 0x63, 0x61, 0x73, 0x65, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x47,
+	0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e,
+	0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74,
+	0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x09, 0x49, 0x6e, 0x63, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65,
+	0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65,
+	0x12, 0x40, 0x0a, 0x05, 0x77, 0x65, 0x65, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6e, 0x63, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x77, 0x65, 0x65,
+	0x6b, 0x73, 0x22, 0xbc, 0x01, 0x0a, 0x0f, 0x49, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x05, 0x63, 0x61, 0x73, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
+	0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x43, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x05, 0x63, 0x61, 0x73, 0x65,
+	0x73, 0x22, 0x53, 0x0a, 0x09, 0x43, 0x61, 0x73, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x63, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x69, 0x6e, 0x63,
+	0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x22, 0x6b, 0x0a, 0x16, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x5f, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x12, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x46, 0x69, 0x72, 0x73, 0x74, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x22, 0x9b, 0x02, 0x0a, 0x17, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x35, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x73, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x75, 0x64, 0x79, 0x5f,
+	0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x75, 0x64, 0x79,
+	0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x5f, 0x6b, 0x65,
+	0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x72, 0x76, 0x65, 0x79, 0x4b,
+	0x65, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x69, 0x6d, 0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65,
+	0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63,
+	0x69, 0x70, 0x61, 0x6e, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x73, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65,
+	0x73, 0x22, 0x9a, 0x01, 0x0a, 0x12, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x75, 0x72, 0x76,
+	0x65, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x75,
+	0x72, 0x76, 0x65, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d, 0x65, 0x7a,
+	0x6f, 0x6e, 0x65, 0x12, 0x49, 0x0a, 0x05, 0x77, 0x65, 0x65, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x33, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
+	0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x77, 0x65, 0x65, 0x6b, 0x73, 0x22, 0xa3,
+	0x01, 0x0a, 0x18, 0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69,
+	0x70, 0x61, 0x6e, 0x74, 0x73, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x61, 0x62, 0x65, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x74,
+	0x69, 0x76, 0x65, 0x5f, 0x70, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x70, 0x6f, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x73, 0x32, 0xc0, 0x07, 0x0a, 0x0e, 0x44, 0x61, 0x74, 0x61, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x41, 0x70, 0x69, 0x12, 0x44, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x22, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x5f, 0x0a,
+	0x0f, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x43, 0x53, 0x56,
+	0x12, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e,
+	0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66,
+	0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x62,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x43,
+	0x53, 0x56, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53,
+	0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x20,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x30, 0x01, 0x12, 0x62, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x2a, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x53, 0x75, 0x72, 0x76, 0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x25, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x53, 0x75, 0x72, 0x76,
+	0x65, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x7a, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12,
+	0x32, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x73, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x7a, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x32, 0x2e, 0x69, 0x6e,
+	0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x65,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x57, 0x65, 0x65, 0x6b, 0x6c, 0x79, 0x49, 0x6e, 0x63, 0x69, 0x64,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61,
+	0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x49, 0x6e, 0x63, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a,
+	0x24, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64,
+	0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x49, 0x6e, 0x63, 0x69,
+	0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x7a, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x12, 0x32,
+	0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61,
+	0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76,
+	0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x73, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x1a, 0x2d, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65,
+	0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74,
+	0x73, 0x12, 0x64, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x51, 0x75, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x28, 0x2e, 0x69, 0x6e, 0x66, 0x6c,
+	0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e, 0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x51, 0x75,
+	0x65, 0x72, 0x79, 0x1a, 0x20, 0x2e, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2e, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6e, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x7a, 0x61, 0x6e,
+	0x65, 0x74, 0x2f, 0x64, 0x61, 0x74, 0x61, 0x2d, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f,
+	0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_data_service_data_service_proto_rawDescOnce sync.Once
+	file_data_service_data_service_proto_rawDescData = file_data_service_data_service_proto_rawDesc
+)
+
+func file_data_service_data_service_proto_rawDescGZIP() []byte {
+	file_data_service_data_service_proto_rawDescOnce.Do(func() {
+		file_data_service_data_service_proto_rawDescData = protoimpl.X.CompressGZIP(file_data_service_data_service_proto_rawDescData)
+	})
+	return file_data_service_data_service_proto_rawDescData
+}
+
+var file_data_service_data_service_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_data_service_data_service_proto_goTypes = []interface{}{
+	(*ResponseQuery)(nil),            // 0: influenzanet.data_service.ResponseQuery
+	(*SurveyInfoQuery)(nil),          // 1: influenzanet.data_service.SurveyInfoQuery
+	(*Chunk)(nil),                    // 2: influenzanet.data_service.Chunk
+	(*SurveyInfo)(nil),               // 3: influenzanet.data_service.SurveyInfo
+	(*SurveyVersionPreview)(nil),     // 4: influenzanet.data_service.SurveyVersionPreview
+	(*SurveyQuestion)(nil),           // 5: influenzanet.data_service.SurveyQuestion
+	(*ResponseDef)(nil),              // 6: influenzanet.data_service.ResponseDef
+	(*ResponseOption)(nil),           // 7: influenzanet.data_service.ResponseOption
+	(*ResponseStatisticsQuery)(nil),  // 8: influenzanet.data_service.ResponseStatisticsQuery
+	(*ResponseStatistics)(nil),       // 9: influenzanet.data_service.ResponseStatistics
+	(*QuestionStatistics)(nil),       // 10: influenzanet.data_service.QuestionStatistics
+	(*ResponseSlotStatistics)(nil),   // 11: influenzanet.data_service.ResponseSlotStatistics
+	(*NumericSummary)(nil),           // 12: influenzanet.data_service.NumericSummary
+	(*Quantile)(nil),                 // 13: influenzanet.data_service.Quantile
+	(*ResponseTimeSeriesQuery)(nil),  // 14: influenzanet.data_service.ResponseTimeSeriesQuery
+	(*ResponseTimeSeries)(nil),       // 15: influenzanet.data_service.ResponseTimeSeries
+	(*TimeSeriesBucket)(nil),         // 16: influenzanet.data_service.TimeSeriesBucket
+	(*CaseDefinition)(nil),           // 17: influenzanet.data_service.CaseDefinition
+	(*CaseRule)(nil),                 // 18: influenzanet.data_service.CaseRule
+	(*ColumnMapping)(nil),            // 19: influenzanet.data_service.ColumnMapping
+	(*TimestampFormat)(nil),          // 20: influenzanet.data_service.TimestampFormat
+	(*CSVDialect)(nil),               // 21: influenzanet.data_service.CSVDialect
+	(*BooleanEncoding)(nil),          // 22: influenzanet.data_service.BooleanEncoding
+	(*ArrayEncoding)(nil),            // 23: influenzanet.data_service.ArrayEncoding
+	(*MissingValueCoding)(nil),       // 24: influenzanet.data_service.MissingValueCoding
+	(*DerivedColumn)(nil),            // 25: influenzanet.data_service.DerivedColumn
+	(*IncidenceQuery)(nil),           // 26: influenzanet.data_service.IncidenceQuery
+	(*Incidence)(nil),                // 27: influenzanet.data_service.Incidence
+	(*IncidenceBucket)(nil),          // 28: influenzanet.data_service.IncidenceBucket
+	(*CaseCount)(nil),                // 29: influenzanet.data_service.CaseCount
+	(*ActiveParticipantRules)(nil),   // 30: influenzanet.data_service.ActiveParticipantRules
+	(*ActiveParticipantsQuery)(nil),  // 31: influenzanet.data_service.ActiveParticipantsQuery
+	(*ActiveParticipants)(nil),       // 32: influenzanet.data_service.ActiveParticipants
+	(*ActiveParticipantsBucket)(nil), // 33: influenzanet.data_service.ActiveParticipantsBucket
+	nil,                              // 34: influenzanet.data_service.ResponseSlotStatistics.OptionCountsEntry
+	(*api_types.TokenInfos)(nil),     // 35: influenzanet.shared.TokenInfos
+	(*emptypb.Empty)(nil),            // 36: google.protobuf.Empty
+	(*api_types.ServiceStatus)(nil),  // 37: influenzanet.shared.ServiceStatus
+}
+var file_data_service_data_service_proto_depIdxs = []int32{
+	35, // 0: influenzanet.data_service.ResponseQuery.token:type_name -> influenzanet.shared.TokenInfos
+	17, // 1: influenzanet.data_service.ResponseQuery.case_definitions:type_name -> influenzanet.data_service.CaseDefinition
+	25, // 2: influenzanet.data_service.ResponseQuery.derived_columns:type_name -> influenzanet.data_service.DerivedColumn
+	24, // 3: influenzanet.data_service.ResponseQuery.missing_values:type_name -> influenzanet.data_service.MissingValueCoding
+	19, // 4: influenzanet.data_service.ResponseQuery.column_mappings:type_name -> influenzanet.data_service.ColumnMapping
+	20, // 5: influenzanet.data_service.ResponseQuery.timestamp_format:type_name -> influenzanet.data_service.TimestampFormat
+	22, // 6: influenzanet.data_service.ResponseQuery.boolean_encoding:type_name -> influenzanet.data_service.BooleanEncoding
+	23, // 7: influenzanet.data_service.ResponseQuery.array_encoding:type_name -> influenzanet.data_service.ArrayEncoding
+	21, // 8: influenzanet.data_service.ResponseQuery.csv_dialect:type_name -> influenzanet.data_service.CSVDialect
+	35, // 9: influenzanet.data_service.SurveyInfoQuery.token:type_name -> influenzanet.shared.TokenInfos
+	21, // 10: influenzanet.data_service.SurveyInfoQuery.csv_dialect:type_name -> influenzanet.data_service.CSVDialect
+	4,  // 11: influenzanet.data_service.SurveyInfo.versions:type_name -> influenzanet.data_service.SurveyVersionPreview
+	5,  // 12: influenzanet.data_service.SurveyVersionPreview.questions:type_name -> influenzanet.data_service.SurveyQuestion
+	6,  // 13: influenzanet.data_service.SurveyQuestion.responses:type_name -> influenzanet.data_service.ResponseDef
+	7,  // 14: influenzanet.data_service.ResponseDef.options:type_name -> influenzanet.data_service.ResponseOption
+	35, // 15: influenzanet.data_service.ResponseStatisticsQuery.token:type_name -> influenzanet.shared.TokenInfos
+	10, // 16: influenzanet.data_service.ResponseStatistics.questions:type_name -> influenzanet.data_service.QuestionStatistics
+	11, // 17: influenzanet.data_service.QuestionStatistics.slots:type_name -> influenzanet.data_service.ResponseSlotStatistics
+	34, // 18: influenzanet.data_service.ResponseSlotStatistics.option_counts:type_name -> influenzanet.data_service.ResponseSlotStatistics.OptionCountsEntry
+	12, // 19: influenzanet.data_service.ResponseSlotStatistics.numeric:type_name -> influenzanet.data_service.NumericSummary
+	13, // 20: influenzanet.data_service.NumericSummary.quantiles:type_name -> influenzanet.data_service.Quantile
+	35, // 21: influenzanet.data_service.ResponseTimeSeriesQuery.token:type_name -> influenzanet.shared.TokenInfos
+	16, // 22: influenzanet.data_service.ResponseTimeSeries.buckets:type_name -> influenzanet.data_service.TimeSeriesBucket
+	10, // 23: influenzanet.data_service.TimeSeriesBucket.questions:type_name -> influenzanet.data_service.QuestionStatistics
+	18, // 24: influenzanet.data_service.CaseDefinition.rule:type_name -> influenzanet.data_service.CaseRule
+	18, // 25: influenzanet.data_service.CaseRule.rules:type_name -> influenzanet.data_service.CaseRule
+	35, // 26: influenzanet.data_service.IncidenceQuery.token:type_name -> influenzanet.shared.TokenInfos
+	17, // 27: influenzanet.data_service.IncidenceQuery.case_definitions:type_name -> influenzanet.data_service.CaseDefinition
+	30, // 28: influenzanet.data_service.IncidenceQuery.rules:type_name -> influenzanet.data_service.ActiveParticipantRules
+	28, // 29: influenzanet.data_service.Incidence.weeks:type_name -> influenzanet.data_service.IncidenceBucket
+	29, // 30: influenzanet.data_service.IncidenceBucket.cases:type_name -> influenzanet.data_service.CaseCount
+	35, // 31: influenzanet.data_service.ActiveParticipantsQuery.token:type_name -> influenzanet.shared.TokenInfos
+	30, // 32: influenzanet.data_service.ActiveParticipantsQuery.rules:type_name -> influenzanet.data_service.ActiveParticipantRules
+	33, // 33: influenzanet.data_service.ActiveParticipants.weeks:type_name -> influenzanet.data_service.ActiveParticipantsBucket
+	36, // 34: influenzanet.data_service.DataServiceApi.Status:input_type -> google.protobuf.Empty
+	0,  // 35: influenzanet.data_service.DataServiceApi.GetResponsesCSV:input_type -> influenzanet.data_service.ResponseQuery
+	1,  // 36: influenzanet.data_service.DataServiceApi.GetSurveyInfoCSV:input_type -> influenzanet.data_service.SurveyInfoQuery
+	1,  // 37: influenzanet.data_service.DataServiceApi.GetSurveyInfo:input_type -> influenzanet.data_service.SurveyInfoQuery
+	8,  // 38: influenzanet.data_service.DataServiceApi.GetResponseStatistics:input_type -> influenzanet.data_service.ResponseStatisticsQuery
+	14, // 39: influenzanet.data_service.DataServiceApi.GetResponseTimeSeries:input_type -> influenzanet.data_service.ResponseTimeSeriesQuery
+	26, // 40: influenzanet.data_service.DataServiceApi.GetWeeklyIncidence:input_type -> influenzanet.data_service.IncidenceQuery
+	31, // 41: influenzanet.data_service.DataServiceApi.GetActiveParticipants:input_type -> influenzanet.data_service.ActiveParticipantsQuery
+	0,  // 42: influenzanet.data_service.DataServiceApi.GetDataQualityReport:input_type -> influenzanet.data_service.ResponseQuery
+	37, // 43: influenzanet.data_service.DataServiceApi.Status:output_type -> influenzanet.shared.ServiceStatus
+	2,  // 44: influenzanet.data_service.DataServiceApi.GetResponsesCSV:output_type -> influenzanet.data_service.Chunk
+	2,  // 45: influenzanet.data_service.DataServiceApi.GetSurveyInfoCSV:output_type -> influenzanet.data_service.Chunk
+	3,  // 46: influenzanet.data_service.DataServiceApi.GetSurveyInfo:output_type -> influenzanet.data_service.SurveyInfo
+	9,  // 47: influenzanet.data_service.DataServiceApi.GetResponseStatistics:output_type -> influenzanet.data_service.ResponseStatistics
+	15, // 48: influenzanet.data_service.DataServiceApi.GetResponseTimeSeries:output_type -> influenzanet.data_service.ResponseTimeSeries
+	27, // 49: influenzanet.data_service.DataServiceApi.GetWeeklyIncidence:output_type -> influenzanet.data_service.Incidence
+	32, // 50: influenzanet.data_service.DataServiceApi.GetActiveParticipants:output_type -> influenzanet.data_service.ActiveParticipants
+	2,  // 51: influenzanet.data_service.DataServiceApi.GetDataQualityReport:output_type -> influenzanet.data_service.Chunk
+	43, // [43:52] is the sub-list for method output_type
+	34, // [34:43] is the sub-list for method input_type
+	34, // [34:34] is the sub-list for extension type_name
+	34, // [34:34] is the sub-list for extension extendee
+	0,  // [0:34] is the sub-list for field type_name
+}
+
+func init() { file_data_service_data_service_proto_init() }
+func file_data_service_data_service_proto_init() {
+	if File_data_service_data_service_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_data_service_data_service_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyInfoQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Chunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyVersionPreview); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SurveyQuestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseDef); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseStatisticsQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuestionStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseSlotStatistics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NumericSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Quantile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseTimeSeriesQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseTimeSeries); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeSeriesBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaseDefinition); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaseRule); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ColumnMapping); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimestampFormat); i {
+			case 0:
+				return &v.state
 			case 1:
 				return &v.sizeCache
 			case 2:
@@ -850,8 +3622,152 @@ func file_data_service_data_service_proto_init() {
 				return nil
 			}
 		}
-		file_data_service_data_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseOption); i {
+		file_data_service_data_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CSVDialect); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BooleanEncoding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArrayEncoding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MissingValueCoding); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DerivedColumn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncidenceQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Incidence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IncidenceBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CaseCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveParticipantRules); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveParticipantsQuery); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveParticipants); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_data_service_data_service_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActiveParticipantsBucket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -869,7 +3785,7 @@ func file_data_service_data_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_data_service_data_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -895,10 +3811,17 @@ const _ = grpc.SupportPackageIsVersion6
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type DataServiceApiClient interface {
-	Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*api_types.ServiceStatus, error)
+	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*api_types.ServiceStatus, error)
 	GetResponsesCSV(ctx context.Context, in *ResponseQuery, opts ...grpc.CallOption) (DataServiceApi_GetResponsesCSVClient, error)
 	GetSurveyInfoCSV(ctx context.Context, in *SurveyInfoQuery, opts ...grpc.CallOption) (DataServiceApi_GetSurveyInfoCSVClient, error)
 	GetSurveyInfo(ctx context.Context, in *SurveyInfoQuery, opts ...grpc.CallOption) (*SurveyInfo, error)
+	GetResponseStatistics(ctx context.Context, in *ResponseStatisticsQuery, opts ...grpc.CallOption) (*ResponseStatistics, error)
+	GetResponseTimeSeries(ctx context.Context, in *ResponseTimeSeriesQuery, opts ...grpc.CallOption) (*ResponseTimeSeries, error)
+	GetWeeklyIncidence(ctx context.Context, in *IncidenceQuery, opts ...grpc.CallOption) (*Incidence, error)
+	GetActiveParticipants(ctx context.Context, in *ActiveParticipantsQuery, opts ...grpc.CallOption) (*ActiveParticipants, error)
+	// only the data-quality report, to check the responses without downloading them. GetResponsesCSV sends
+	// the report with the export if include_quality_report is set.
+	GetDataQualityReport(ctx context.Context, in *ResponseQuery, opts ...grpc.CallOption) (DataServiceApi_GetDataQualityReportClient, error)
 }
 
 type dataServiceApiClient struct {
@@ -909,7 +3832,7 @@ func NewDataServiceApiClient(cc grpc.ClientConnInterface) DataServiceApiClient {
 	return &dataServiceApiClient{cc}
 }
 
-func (c *dataServiceApiClient) Status(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*api_types.ServiceStatus, error) {
+func (c *dataServiceApiClient) Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*api_types.ServiceStatus, error) {
 	out := new(api_types.ServiceStatus)
 	err := c.cc.Invoke(ctx, "/influenzanet.data_service.DataServiceApi/Status", in, out, opts...)
 	if err != nil {
@@ -991,19 +3914,94 @@ func (c *dataServiceApiClient) GetSurveyInfo(ctx context.Context, in *SurveyInfo
 	return out, nil
 }
 
+func (c *dataServiceApiClient) GetResponseStatistics(ctx context.Context, in *ResponseStatisticsQuery, opts ...grpc.CallOption) (*ResponseStatistics, error) {
+	out := new(ResponseStatistics)
+	err := c.cc.Invoke(ctx, "/influenzanet.data_service.DataServiceApi/GetResponseStatistics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceApiClient) GetResponseTimeSeries(ctx context.Context, in *ResponseTimeSeriesQuery, opts ...grpc.CallOption) (*ResponseTimeSeries, error) {
+	out := new(ResponseTimeSeries)
+	err := c.cc.Invoke(ctx, "/influenzanet.data_service.DataServiceApi/GetResponseTimeSeries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceApiClient) GetWeeklyIncidence(ctx context.Context, in *IncidenceQuery, opts ...grpc.CallOption) (*Incidence, error) {
+	out := new(Incidence)
+	err := c.cc.Invoke(ctx, "/influenzanet.data_service.DataServiceApi/GetWeeklyIncidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceApiClient) GetActiveParticipants(ctx context.Context, in *ActiveParticipantsQuery, opts ...grpc.CallOption) (*ActiveParticipants, error) {
+	out := new(ActiveParticipants)
+	err := c.cc.Invoke(ctx, "/influenzanet.data_service.DataServiceApi/GetActiveParticipants", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataServiceApiClient) GetDataQualityReport(ctx context.Context, in *ResponseQuery, opts ...grpc.CallOption) (DataServiceApi_GetDataQualityReportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DataServiceApi_serviceDesc.Streams[2], "/influenzanet.data_service.DataServiceApi/GetDataQualityReport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dataServiceApiGetDataQualityReportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DataServiceApi_GetDataQualityReportClient interface {
+	Recv() (*Chunk, error)
+	grpc.ClientStream
+}
+
+type dataServiceApiGetDataQualityReportClient struct {
+	grpc.ClientStream
+}
+
+func (x *dataServiceApiGetDataQualityReportClient) Recv() (*Chunk, error) {
+	m := new(Chunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DataServiceApiServer is the server API for DataServiceApi service.
 type DataServiceApiServer interface {
-	Status(context.Context, *empty.Empty) (*api_types.ServiceStatus, error)
+	Status(context.Context, *emptypb.Empty) (*api_types.ServiceStatus, error)
 	GetResponsesCSV(*ResponseQuery, DataServiceApi_GetResponsesCSVServer) error
 	GetSurveyInfoCSV(*SurveyInfoQuery, DataServiceApi_GetSurveyInfoCSVServer) error
 	GetSurveyInfo(context.Context, *SurveyInfoQuery) (*SurveyInfo, error)
+	GetResponseStatistics(context.Context, *ResponseStatisticsQuery) (*ResponseStatistics, error)
+	GetResponseTimeSeries(context.Context, *ResponseTimeSeriesQuery) (*ResponseTimeSeries, error)
+	GetWeeklyIncidence(context.Context, *IncidenceQuery) (*Incidence, error)
+	GetActiveParticipants(context.Context, *ActiveParticipantsQuery) (*ActiveParticipants, error)
+	// only the data-quality report, to check the responses without downloading them. GetResponsesCSV sends
+	// the report with the export if include_quality_report is set.
+	GetDataQualityReport(*ResponseQuery, DataServiceApi_GetDataQualityReportServer) error
 }
 
 // UnimplementedDataServiceApiServer can be embedded to have forward compatible implementations.
 type UnimplementedDataServiceApiServer struct {
 }
 
-func (*UnimplementedDataServiceApiServer) Status(context.Context, *empty.Empty) (*api_types.ServiceStatus, error) {
+func (*UnimplementedDataServiceApiServer) Status(context.Context, *emptypb.Empty) (*api_types.ServiceStatus, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
 func (*UnimplementedDataServiceApiServer) GetResponsesCSV(*ResponseQuery, DataServiceApi_GetResponsesCSVServer) error {
@@ -1015,13 +4013,28 @@ func (*UnimplementedDataServiceApiServer) GetSurveyInfoCSV(*SurveyInfoQuery, Dat
 func (*UnimplementedDataServiceApiServer) GetSurveyInfo(context.Context, *SurveyInfoQuery) (*SurveyInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSurveyInfo not implemented")
 }
+func (*UnimplementedDataServiceApiServer) GetResponseStatistics(context.Context, *ResponseStatisticsQuery) (*ResponseStatistics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResponseStatistics not implemented")
+}
+func (*UnimplementedDataServiceApiServer) GetResponseTimeSeries(context.Context, *ResponseTimeSeriesQuery) (*ResponseTimeSeries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResponseTimeSeries not implemented")
+}
+func (*UnimplementedDataServiceApiServer) GetWeeklyIncidence(context.Context, *IncidenceQuery) (*Incidence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWeeklyIncidence not implemented")
+}
+func (*UnimplementedDataServiceApiServer) GetActiveParticipants(context.Context, *ActiveParticipantsQuery) (*ActiveParticipants, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveParticipants not implemented")
+}
+func (*UnimplementedDataServiceApiServer) GetDataQualityReport(*ResponseQuery, DataServiceApi_GetDataQualityReportServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetDataQualityReport not implemented")
+}
 
 func RegisterDataServiceApiServer(s *grpc.Server, srv DataServiceApiServer) {
 	s.RegisterService(&_DataServiceApi_serviceDesc, srv)
 }
 
 func _DataServiceApi_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(empty.Empty)
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -1033,7 +4046,7 @@ func _DataServiceApi_Status_Handler(srv interface{}, ctx context.Context, dec fu
 		FullMethod: "/influenzanet.data_service.DataServiceApi/Status",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataServiceApiServer).Status(ctx, req.(*empty.Empty))
+		return srv.(DataServiceApiServer).Status(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -1098,6 +4111,99 @@ func _DataServiceApi_GetSurveyInfo_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DataServiceApi_GetResponseStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResponseStatisticsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceApiServer).GetResponseStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.data_service.DataServiceApi/GetResponseStatistics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceApiServer).GetResponseStatistics(ctx, req.(*ResponseStatisticsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServiceApi_GetResponseTimeSeries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResponseTimeSeriesQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceApiServer).GetResponseTimeSeries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.data_service.DataServiceApi/GetResponseTimeSeries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceApiServer).GetResponseTimeSeries(ctx, req.(*ResponseTimeSeriesQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServiceApi_GetWeeklyIncidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncidenceQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceApiServer).GetWeeklyIncidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.data_service.DataServiceApi/GetWeeklyIncidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceApiServer).GetWeeklyIncidence(ctx, req.(*IncidenceQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServiceApi_GetActiveParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActiveParticipantsQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataServiceApiServer).GetActiveParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/influenzanet.data_service.DataServiceApi/GetActiveParticipants",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataServiceApiServer).GetActiveParticipants(ctx, req.(*ActiveParticipantsQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataServiceApi_GetDataQualityReport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ResponseQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DataServiceApiServer).GetDataQualityReport(m, &dataServiceApiGetDataQualityReportServer{stream})
+}
+
+type DataServiceApi_GetDataQualityReportServer interface {
+	Send(*Chunk) error
+	grpc.ServerStream
+}
+
+type dataServiceApiGetDataQualityReportServer struct {
+	grpc.ServerStream
+}
+
+func (x *dataServiceApiGetDataQualityReportServer) Send(m *Chunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _DataServiceApi_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "influenzanet.data_service.DataServiceApi",
 	HandlerType: (*DataServiceApiServer)(nil),
@@ -1110,6 +4216,22 @@ var _DataServiceApi_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSurveyInfo",
 			Handler:    _DataServiceApi_GetSurveyInfo_Handler,
 		},
+		{
+			MethodName: "GetResponseStatistics",
+			Handler:    _DataServiceApi_GetResponseStatistics_Handler,
+		},
+		{
+			MethodName: "GetResponseTimeSeries",
+			Handler:    _DataServiceApi_GetResponseTimeSeries_Handler,
+		},
+		{
+			MethodName: "GetWeeklyIncidence",
+			Handler:    _DataServiceApi_GetWeeklyIncidence_Handler,
+		},
+		{
+			MethodName: "GetActiveParticipants",
+			Handler:    _DataServiceApi_GetActiveParticipants_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1122,6 +4244,11 @@ var _DataServiceApi_serviceDesc = grpc.ServiceDesc{
 			Handler:       _DataServiceApi_GetSurveyInfoCSV_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetDataQualityReport",
+			Handler:       _DataServiceApi_GetDataQualityReport_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "data_service/data-service.proto",
 }
@@ -0,0 +1,90 @@
+package export_policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/go-utils/pkg/token_checks"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// Policy declares who may access the data of which study. If no rules are defined, every request is
+// allowed and checking permissions is left to the study service.
+type Policy struct {
+	// used for the pseudonymisation of participant IDs
+	PseudonymisationKey string `json:"pseudonymisationKey"`
+	// the first matching rule is applied, so more privileged rules should come first
+	Rules []Rule `json:"rules"`
+}
+
+// Rule grants access to users with all of the listed roles and payload claims
+type Rule struct {
+	Name   string            `json:"name"`
+	Roles  []string          `json:"roles"`
+	Claims map[string]string `json:"claims"`
+	// if empty, all studies are allowed
+	Studies      []string     `json:"studies"`
+	Restrictions Restrictions `json:"restrictions"`
+}
+
+// Restrictions limit the data included in an export
+type Restrictions struct {
+	Pseudonymised bool `json:"pseudonymised"`
+	NoFreeText    bool `json:"noFreeText"`
+}
+
+// Validate checks the policy for incomplete rules
+func (p Policy) Validate() error {
+	for i, r := range p.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule %d: missing name", i)
+		}
+		if len(r.Roles) == 0 && len(r.Claims) == 0 {
+			return fmt.Errorf("rule %s: at least one role or claim is required", r.Name)
+		}
+		if r.Restrictions.Pseudonymised && p.PseudonymisationKey == "" {
+			return fmt.Errorf("rule %s: pseudonymisation needs a key", r.Name)
+		}
+	}
+	return nil
+}
+
+// Check finds the rule for the user and study and returns its restrictions
+func (p Policy) Check(token *api_types.TokenInfos, studyKey string) (Restrictions, error) {
+	if len(p.Rules) == 0 {
+		return Restrictions{}, nil
+	}
+	if token_checks.IsTokenEmpty(token) {
+		return Restrictions{}, ErrPermissionDenied
+	}
+	for _, r := range p.Rules {
+		if r.matches(token, studyKey) {
+			return r.Restrictions, nil
+		}
+	}
+	return Restrictions{}, ErrPermissionDenied
+}
+
+func (r Rule) matches(token *api_types.TokenInfos, studyKey string) bool {
+	for _, role := range r.Roles {
+		if !token_checks.CheckRoleInToken(token, role) {
+			return false
+		}
+	}
+	for k, v := range r.Claims {
+		if token.Payload[k] != v {
+			return false
+		}
+	}
+	if len(r.Studies) == 0 {
+		return true
+	}
+	for _, s := range r.Studies {
+		if s == studyKey {
+			return true
+		}
+	}
+	return false
+}
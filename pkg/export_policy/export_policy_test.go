@@ -0,0 +1,121 @@
+package export_policy
+
+import (
+	"testing"
+
+	"github.com/influenzanet/go-utils/pkg/api_types"
+)
+
+func mockToken(roles string, payload map[string]string) *api_types.TokenInfos {
+	p := map[string]string{"roles": roles}
+	for k, v := range payload {
+		p[k] = v
+	}
+	return &api_types.TokenInfos{
+		Id:         "user1",
+		InstanceId: "test",
+		Payload:    p,
+	}
+}
+
+var testPolicy = Policy{
+	PseudonymisationKey: "secret",
+	Rules: []Rule{
+		{Name: "admin", Roles: []string{"ADMIN"}},
+		{Name: "researcher", Roles: []string{"RESEARCHER"}, Studies: []string{"study1"}},
+		{
+			Name:         "partner",
+			Roles:        []string{"PARTNER"},
+			Claims:       map[string]string{"institution": "uni"},
+			Studies:      []string{"study1", "study2"},
+			Restrictions: Restrictions{Pseudonymised: true, NoFreeText: true},
+		},
+	},
+}
+
+func TestValidate(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		if err := testPolicy.Validate(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		p := Policy{Rules: []Rule{{Roles: []string{"ADMIN"}}}}
+		if err := p.Validate(); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("rule without roles or claims", func(t *testing.T) {
+		p := Policy{Rules: []Rule{{Name: "everyone"}}}
+		if err := p.Validate(); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("pseudonymisation without key", func(t *testing.T) {
+		p := Policy{Rules: []Rule{{Name: "partner", Roles: []string{"PARTNER"}, Restrictions: Restrictions{Pseudonymised: true}}}}
+		if err := p.Validate(); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	testCases := []struct {
+		name         string
+		policy       Policy
+		token        *api_types.TokenInfos
+		studyKey     string
+		denied       bool
+		restrictions Restrictions
+	}{
+		{name: "empty policy allows all", policy: Policy{}, token: mockToken("", nil), studyKey: "study3"},
+		{name: "empty token", policy: testPolicy, token: nil, studyKey: "study1", denied: true},
+		{name: "admin", policy: testPolicy, token: mockToken("ADMIN", nil), studyKey: "study3"},
+		{name: "researcher of allowed study", policy: testPolicy, token: mockToken("RESEARCHER", nil), studyKey: "study1"},
+		{name: "researcher of other study", policy: testPolicy, token: mockToken("RESEARCHER", nil), studyKey: "study2", denied: true},
+		{
+			name:         "partner",
+			policy:       testPolicy,
+			token:        mockToken("PARTNER", map[string]string{"institution": "uni"}),
+			studyKey:     "study2",
+			restrictions: Restrictions{Pseudonymised: true, NoFreeText: true},
+		},
+		{name: "partner with wrong claim", policy: testPolicy, token: mockToken("PARTNER", map[string]string{"institution": "other"}), studyKey: "study2", denied: true},
+		{
+			name:         "researcher and partner",
+			policy:       testPolicy,
+			token:        mockToken("RESEARCHER,PARTNER", map[string]string{"institution": "uni"}),
+			studyKey:     "study1",
+			restrictions: Restrictions{},
+		},
+		{
+			name:         "researcher and partner for partner study",
+			policy:       testPolicy,
+			token:        mockToken("RESEARCHER,PARTNER", map[string]string{"institution": "uni"}),
+			studyKey:     "study2",
+			restrictions: Restrictions{Pseudonymised: true, NoFreeText: true},
+		},
+		{name: "participant", policy: testPolicy, token: mockToken("PARTICIPANT", nil), studyKey: "study1", denied: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := tc.policy.Check(tc.token, tc.studyKey)
+			if tc.denied {
+				if err != ErrPermissionDenied {
+					t.Errorf("should be denied, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if r != tc.restrictions {
+				t.Errorf("unexpected restrictions: %v", r)
+			}
+		})
+	}
+}
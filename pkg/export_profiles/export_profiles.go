@@ -0,0 +1,72 @@
+package export_profiles
+
+import (
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/influenzanet/data-service/pkg/response_parser"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/go-utils/pkg/token_checks"
+)
+
+var (
+	ErrUnknownProfile   = errors.New("unknown export profile")
+	ErrPermissionDenied = errors.New("export profile not allowed")
+)
+
+// Profile bundles the format and content options of an export under a name
+type Profile struct {
+	Description string `json:"description"`
+	// the caller needs one of the roles, if empty the profile can be used by everyone
+	Roles             []string `json:"roles"`
+	ShortQuestionKeys bool     `json:"shortQuestionKeys"`
+	Separator         string   `json:"separator"`
+	IncludeMeta       bool     `json:"includeMeta"`
+	IncludeTiming     bool     `json:"includeTiming"`
+	// patterns of the included and excluded columns, see ResponseParser.SetColumnFilter
+	Columns        []string `json:"columns"`
+	ExcludeColumns []string `json:"excludeColumns"`
+	Pseudonymised  bool     `json:"pseudonymised"`
+	NoFreeText     bool     `json:"noFreeText"`
+	// if set, option labels of this language are written instead of the keys
+	Language        string                              `json:"language"`
+	Compression     string                              `json:"compression"`
+	TimestampFormat response_parser.TimestampFormat     `json:"timestampFormat"`
+	BooleanEncoding response_parser.BooleanEncoding     `json:"booleanEncoding"`
+	ArrayEncoding   response_parser.ArrayEncoding       `json:"arrayEncoding"`
+	CSVDialect      response_parser.CSVDialect          `json:"csvDialect"`
+	MissingValues   *response_parser.MissingValueCoding `json:"missingValues"`
+}
+
+// Profiles are the export profiles by name
+type Profiles map[string]Profile
+
+// Validate checks the column patterns of all profiles
+func (p Profiles) Validate() error {
+	for name, profile := range p {
+		for _, c := range append(append([]string{}, profile.Columns...), profile.ExcludeColumns...) {
+			if _, err := path.Match(c, ""); err != nil {
+				return fmt.Errorf("profile %s: invalid column pattern: %s", name, c)
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the profile if the user is allowed to use it
+func (p Profiles) Get(name string, token *api_types.TokenInfos) (Profile, error) {
+	profile, ok := p[name]
+	if !ok {
+		return Profile{}, ErrUnknownProfile
+	}
+	if len(profile.Roles) == 0 {
+		return profile, nil
+	}
+	for _, role := range profile.Roles {
+		if token_checks.CheckRoleInToken(token, role) {
+			return profile, nil
+		}
+	}
+	return Profile{}, ErrPermissionDenied
+}
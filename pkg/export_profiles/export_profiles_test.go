@@ -0,0 +1,58 @@
+package export_profiles
+
+import (
+	"testing"
+
+	"github.com/influenzanet/go-utils/pkg/api_types"
+)
+
+func mockToken(roles string) *api_types.TokenInfos {
+	return &api_types.TokenInfos{
+		Id:         "user1",
+		InstanceId: "test",
+		Payload:    map[string]string{"roles": roles},
+	}
+}
+
+var testProfiles = Profiles{
+	"ecdc-weekly":        {ShortQuestionKeys: true, Separator: "-", Columns: []string{"Q1-*"}},
+	"partner-anonymised": {Roles: []string{"PARTNER", "RESEARCHER"}, Pseudonymised: true, NoFreeText: true},
+	"internal-full":      {Roles: []string{"RESEARCHER"}, IncludeMeta: true, IncludeTiming: true},
+}
+
+func TestValidate(t *testing.T) {
+	if err := testProfiles.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	invalid := Profiles{"broken": {ExcludeColumns: []string{"Q1-["}}}
+	if err := invalid.Validate(); err == nil {
+		t.Error("should fail with error")
+	}
+}
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		name     string
+		profile  string
+		token    *api_types.TokenInfos
+		expected error
+	}{
+		{name: "unknown profile", profile: "other", token: mockToken("RESEARCHER"), expected: ErrUnknownProfile},
+		{name: "profile without roles", profile: "ecdc-weekly", token: mockToken("")},
+		{name: "one of the roles", profile: "partner-anonymised", token: mockToken("PARTNER")},
+		{name: "missing role", profile: "internal-full", token: mockToken("PARTNER"), expected: ErrPermissionDenied},
+		{name: "allowed role", profile: "internal-full", token: mockToken("ADMIN,RESEARCHER")},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := testProfiles.Get(tc.profile, tc.token)
+			if err != tc.expected {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if err == nil && p.IncludeMeta != testProfiles[tc.profile].IncludeMeta {
+				t.Errorf("unexpected profile: %v", p)
+			}
+		})
+	}
+}
@@ -1,29 +1,45 @@
 package clients
 
 import (
-	"log"
+	"crypto/tls"
 
+	"github.com/influenzanet/data-service/pkg/logger"
+	"github.com/influenzanet/data-service/pkg/metrics"
 	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-func connectToGRPCServer(addr string) *grpc.ClientConn {
-	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+// connectToGRPCServer uses TLS if a config is given
+func connectToGRPCServer(addr string, maxMsgSize int, tlsConf *tls.Config) *grpc.ClientConn {
+	opts := []grpc.DialOption{grpc.WithInsecure()}
+	if tlsConf != nil {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConf))}
+	}
+	opts = append(opts,
+		grpc.WithChainUnaryInterceptor(logger.UnaryClientInterceptor, metrics.UnaryClientInterceptor),
+		grpc.WithChainStreamInterceptor(logger.StreamClientInterceptor, metrics.StreamClientInterceptor),
+	)
+	if maxMsgSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMsgSize)))
+	}
+	conn, err := grpc.Dial(addr, opts...)
 	if err != nil {
-		log.Fatalf("failed to connect to %s: %v", addr, err)
+		zap.L().Fatal("failed to connect", zap.String("addr", addr), zap.Error(err))
 	}
 	return conn
 }
 
-func ConnectToLoggingService(addr string) (client loggingAPI.LoggingServiceApiClient, close func() error) {
+func ConnectToLoggingService(addr string, maxMsgSize int, tlsConf *tls.Config) (client loggingAPI.LoggingServiceApiClient, conn *grpc.ClientConn) {
 	// Connect to logging service
-	serverConn := connectToGRPCServer(addr)
-	return loggingAPI.NewLoggingServiceApiClient(serverConn), serverConn.Close
+	serverConn := connectToGRPCServer(addr, maxMsgSize, tlsConf)
+	return loggingAPI.NewLoggingServiceApiClient(serverConn), serverConn
 }
 
-func ConnectToStudyService(addr string) (client studyAPI.StudyServiceApiClient, close func() error) {
+func ConnectToStudyService(addr string, maxMsgSize int, tlsConf *tls.Config) (client studyAPI.StudyServiceApiClient, conn *grpc.ClientConn) {
 	// Connect to study service
-	serverConn := connectToGRPCServer(addr)
-	return studyAPI.NewStudyServiceApiClient(serverConn), serverConn.Close
+	serverConn := connectToGRPCServer(addr, maxMsgSize, tlsConf)
+	return studyAPI.NewStudyServiceApiClient(serverConn), serverConn
 }
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+
+	"github.com/influenzanet/data-service/pkg/admission"
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/logger"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// a parsed response takes several times the memory of its wire format
+const responseMemoryFactor = 4
+
+// admitExport waits for a free export slot, while waiting the position in the queue is sent to the client
+// as chunks without data. Unary requests pass a nil stream and wait without position updates.
+func (s *dataServiceServer) admitExport(ctx context.Context, token *api_types.TokenInfos, studyKey string, stream chunkStream) (*admission.Ticket, error) {
+	waitCtx := ctx
+	if s.maxQueueWait > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, s.maxQueueWait)
+		defer cancel()
+	}
+	user := token.InstanceId + "/" + token.Id
+	study := token.InstanceId + "/" + studyKey
+	var onPosition func(int)
+	if stream != nil {
+		onPosition = func(position int) {
+			if err := stream.Send(&api.Chunk{QueuePosition: int32(position)}); err != nil {
+				logger.FromContext(ctx).Debug("failed to send queue position", zap.Error(err))
+			}
+		}
+	}
+	ticket, err := s.admission.Acquire(waitCtx, user, study, onPosition)
+	switch {
+	case err == nil:
+		return ticket, nil
+	case err == admission.ErrQueueFull:
+		return nil, status.Error(codes.ResourceExhausted, "too many exports are waiting, please try again later")
+	case ctx.Err() != nil:
+		return nil, s.contextError(ctx)
+	default:
+		return nil, status.Errorf(codes.ResourceExhausted, "no export slot free within %s, please try again later", s.maxQueueWait)
+	}
+}
+
+// reserveResponse accounts for the memory of a response that is added to the parser
+func reserveResponse(ticket *admission.Ticket, r *studyAPI.SurveyResponse) error {
+	if err := ticket.Reserve(responseMemoryFactor * int64(proto.Size(r))); err != nil {
+		return status.Error(codes.ResourceExhausted, "not enough memory available for the export, please select a shorter time range")
+	}
+	return nil
+}
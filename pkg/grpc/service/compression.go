@@ -0,0 +1,123 @@
+package service
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	compressionNone = ""
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
+func checkCompression(compression string) error {
+	switch compression {
+	case compressionNone, compressionGzip, compressionZstd:
+		return nil
+	}
+	return fmt.Errorf("unknown compression: %s", compression)
+}
+
+// chunkWriter sends the written data as chunks of chunkSize bytes, the last chunk is sent on Close.
+// Nothing is sent anymore once ctx is done.
+type chunkWriter struct {
+	ctx    context.Context
+	stream chunkStream
+	buf    []byte
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		free := chunkSize - len(cw.buf)
+		if free > len(p) {
+			free = len(p)
+		}
+		cw.buf = append(cw.buf, p[:free]...)
+		p = p[free:]
+		if len(cw.buf) == chunkSize {
+			if err := cw.flush(); err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+func (cw *chunkWriter) flush() error {
+	if len(cw.buf) == 0 {
+		return nil
+	}
+	if err := cw.ctx.Err(); err != nil {
+		return err
+	}
+	err := cw.stream.Send(&api.Chunk{Chunk: cw.buf})
+	cw.buf = make([]byte, 0, chunkSize)
+	return err
+}
+
+func (cw *chunkWriter) Close() error {
+	return cw.flush()
+}
+
+func (cw *chunkWriter) Abort() {
+	cw.buf = nil
+}
+
+// chunkWriteCloser sends the data when it is closed. Abort is used instead of Close if writing failed,
+// it drops the remaining data and returns once nothing is sent to the stream anymore.
+type chunkWriteCloser interface {
+	io.WriteCloser
+	Abort()
+}
+
+// compressedChunkWriter compresses the data before it is split into chunks
+type compressedChunkWriter struct {
+	io.WriteCloser
+	chunks *chunkWriter
+}
+
+func (w *compressedChunkWriter) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	return w.chunks.Close()
+}
+
+// Abort resets the compressor, the zstd encoder waits for the blocks that it is writing in the background
+func (w *compressedChunkWriter) Abort() {
+	if r, ok := w.WriteCloser.(interface{ Reset(io.Writer) }); ok {
+		r.Reset(ioutil.Discard)
+	}
+	w.chunks.Abort()
+}
+
+// newChunkWriter returns a writer that compresses the data incrementally and sends it to the stream.
+// Close has to be called to send the remaining data, or Abort if writing failed.
+func newChunkWriter(ctx context.Context, compression string, stream chunkStream) (chunkWriteCloser, error) {
+	chunks := &chunkWriter{
+		ctx:    ctx,
+		stream: stream,
+		buf:    make([]byte, 0, chunkSize),
+	}
+	switch compression {
+	case compressionNone:
+		return chunks, nil
+	case compressionGzip:
+		return &compressedChunkWriter{WriteCloser: gzip.NewWriter(chunks), chunks: chunks}, nil
+	case compressionZstd:
+		zw, err := zstd.NewWriter(chunks, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return nil, err
+		}
+		return &compressedChunkWriter{WriteCloser: zw, chunks: chunks}, nil
+	}
+	return nil, checkCompression(compression)
+}
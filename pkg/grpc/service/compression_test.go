@@ -0,0 +1,122 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestChunkWriter(t *testing.T) {
+	// random data does not compress, so the compressed stream spans several chunks
+	data := make([]byte, 3*chunkSize+123)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	testCases := []struct {
+		name        string
+		compression string
+		newReader   func(io.Reader) (io.Reader, error)
+	}{
+		{
+			name:        "without compression",
+			compression: compressionNone,
+			newReader:   func(r io.Reader) (io.Reader, error) { return r, nil },
+		},
+		{
+			name:        "gzip",
+			compression: compressionGzip,
+			newReader:   func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		},
+		{
+			name:        "zstd",
+			compression: compressionZstd,
+			newReader: func(r io.Reader) (io.Reader, error) {
+				d, err := zstd.NewReader(r)
+				if err != nil {
+					return nil, err
+				}
+				return d.IOReadCloser(), nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stream := &mockExportStream{}
+			w, err := newChunkWriter(context.Background(), tc.compression, stream)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// writes that do not line up with the chunk size
+			for rest := data; len(rest) > 0; {
+				n := 1000
+				if n > len(rest) {
+					n = len(rest)
+				}
+				if _, err := w.Write(rest[:n]); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				rest = rest[n:]
+			}
+			sentBeforeClose := stream.sent()
+			if err := w.Close(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(stream.chunks) <= sentBeforeClose {
+				t.Error("remaining data not sent on close")
+			}
+
+			for i, c := range stream.chunks {
+				if len(c.Chunk) == 0 || len(c.Chunk) > chunkSize {
+					t.Errorf("unexpected size of chunk %d: %d", i, len(c.Chunk))
+				}
+				if i < len(stream.chunks)-1 && len(c.Chunk) != chunkSize {
+					t.Errorf("only the last chunk may be smaller: chunk %d has %d bytes", i, len(c.Chunk))
+				}
+			}
+			sent, _ := stream.data()
+			r, err := tc.newReader(bytes.NewReader([]byte(sent)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			received, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(received, data) {
+				t.Errorf("received %d bytes that differ from the %d bytes written", len(received), len(data))
+			}
+		})
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name+" aborted", func(t *testing.T) {
+			stream := &mockExportStream{}
+			w, err := newChunkWriter(context.Background(), tc.compression, stream)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := w.Write(data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			w.Abort()
+			// only full chunks written before the abort are sent, the remaining data is dropped
+			for i, c := range stream.chunks {
+				if len(c.Chunk) != chunkSize {
+					t.Errorf("unexpected size of chunk %d: %d", i, len(c.Chunk))
+				}
+			}
+		})
+	}
+
+	t.Run("with unknown compression", func(t *testing.T) {
+		if _, err := newChunkWriter(context.Background(), "brotli", &mockExportStream{}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+}
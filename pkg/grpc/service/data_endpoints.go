@@ -4,38 +4,117 @@ import (
 	"bytes"
 	"context"
 	"io"
-	"log"
+	"time"
 
 	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/logger"
 	"github.com/influenzanet/data-service/pkg/response_parser"
+	"github.com/influenzanet/go-utils/pkg/constants"
 	"github.com/influenzanet/go-utils/pkg/token_checks"
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
 const chunkSize = 64 * 1024 // 64 KiB
 
-func (s *dataServiceServer) GetResponsesCSV(req *api.ResponseQuery, stream api.DataServiceApi_GetResponsesCSVServer) error {
+func (s *dataServiceServer) GetResponsesCSV(req *api.ResponseQuery, stream api.DataServiceApi_GetResponsesCSVServer) (err error) {
 	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" {
 		return status.Error(codes.InvalidArgument, "missing argument")
 	}
+	export := newExportObserver(req.StudyKey, req.SurveyKey)
+	defer func() { export.done(err) }()
+	ctx, cancel := s.exportContext(stream.Context())
+	defer cancel()
 
-	surveyDef, err := s.clients.StudyService.GetSurveyDefForStudy(context.Background(), &studyAPI.SurveyReferenceRequest{
+	if err := s.checkTimeRange(req.From, req.Until); err != nil {
+		return err
+	}
+	access := dataAccess{
 		Token:     req.Token,
+		EventName: constants.LOG_EVENT_DOWNLOAD_RESPONSES,
 		StudyKey:  req.StudyKey,
 		SurveyKey: req.SurveyKey,
-	})
+		From:      req.From,
+		Until:     req.Until,
+		Profile:   req.Profile,
+	}
+	profile, err := s.exportProfile(ctx, req, access)
+	if err != nil {
+		return err
+	}
+	if err := checkCompression(profile.Compression); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	access.Format = exportFormat("csv", profile.Compression)
+	export.format = access.Format
+	reportFormat, err := checkReportFormat(req.ReportFormat)
+	if err != nil {
+		return err
+	}
+	restrictions, err := s.checkExportPolicy(ctx, access)
+	if err != nil {
+		return err
+	}
+	ticket, err := s.admitExport(ctx, req.Token, req.StudyKey, stream)
+	if err != nil {
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	defer ticket.Release()
+
+	surveyDef, err := s.getSurveyDef(ctx, req.Token, req.StudyKey, req.SurveyKey)
 	if err != nil {
-		return nil
+		s.saveDataAccessLog(ctx, access, err, true)
+		return err
 	}
 
-	rp, err := response_parser.NewResponseParser(surveyDef, "ignored", req.ShortQuestionKeys, req.Separator)
+	lang := profile.Language
+	if lang == "" {
+		lang = "ignored"
+	}
+	rp, err := response_parser.NewResponseParser(surveyDef, lang, profile.ShortQuestionKeys, profile.Separator, logger.FromContext(ctx))
 	if err != nil {
-		return nil
+		s.saveDataAccessLog(ctx, access, err, false)
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := s.applyProfile(rp, profile, restrictions); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+
+	if err := rp.SetCaseDefinitions(caseDefinitionsFromAPI(req.CaseDefinitions)); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	derivedColumns := make([]response_parser.DerivedColumnDef, len(req.DerivedColumns))
+	for i, dc := range req.DerivedColumns {
+		derivedColumns[i] = response_parser.DerivedColumnDefFromAPI(dc)
+	}
+	if err := rp.SetDerivedColumns(derivedColumns); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	columnMappings := make([]response_parser.ColumnMapping, len(req.ColumnMappings))
+	for i, cm := range req.ColumnMappings {
+		columnMappings[i] = response_parser.ColumnMappingFromAPI(cm)
+	}
+	if err := rp.SetColumnMappings(columnMappings); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	if err := rp.SetDeduplication(req.DedupPolicy, time.Duration(req.DuplicateWindowMinutes)*time.Minute); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
 	}
 
-	respStream, err := s.clients.StudyService.StreamStudyResponses(context.Background(), &studyAPI.SurveyResponseQuery{
+	respStream, err := s.clients.StudyService.StreamStudyResponses(ctx, &studyAPI.SurveyResponseQuery{
 		Token:     req.Token,
 		StudyKey:  req.StudyKey,
 		SurveyKey: req.SurveyKey,
@@ -43,37 +122,201 @@ func (s *dataServiceServer) GetResponsesCSV(req *api.ResponseQuery, stream api.D
 		Until:     req.Until,
 	})
 	if err != nil {
+		s.saveDataAccessLog(ctx, access, err, true)
 		return err
 	}
+	count := 0
 	for {
+		if err := s.contextError(ctx); err != nil {
+			s.saveDataAccessLog(ctx, access, err, false)
+			return err
+		}
 		r, err := respStream.Recv()
 		if err == io.EOF {
 			break
 		}
 		if err != nil {
-			log.Printf("GetResponsesCSV(_) = _, %v", err)
-			break
+			if ctxErr := s.contextError(ctx); ctxErr != nil {
+				s.saveDataAccessLog(ctx, access, ctxErr, false)
+				return ctxErr
+			}
+			logger.FromContext(ctx).Error("failed to receive responses", zap.Error(err))
+			s.saveDataAccessLog(ctx, access, err, true)
+			return err
+		}
+		count++
+		if err := checkResponseCount(count, s.limits.MaxResponses); err != nil {
+			s.saveDataAccessLog(ctx, access, err, false)
+			return err
+		}
+		if err := reserveResponse(ticket, r); err != nil {
+			s.saveDataAccessLog(ctx, access, err, false)
+			return err
 		}
 		err = rp.AddResponse(r)
 		if err != nil {
-			log.Printf("GetResponsesCSV.AddResponse(_) = _, %v", err)
+			logger.FromContext(ctx).Warn("failed to parse response", zap.Error(err))
 		}
 	}
 
-	buf := new(bytes.Buffer)
-	err = rp.GetResponsesCSV(buf, req.IncludeMeta)
+	w, err := newChunkWriter(ctx, profile.Compression, export.stream(stream))
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	err = rp.GetResponsesCSV(w, profile.IncludeMeta)
+	if err == nil {
+		err = w.Close()
+	} else {
+		w.Abort()
+	}
+	if err == nil && req.IncludeQualityReport {
+		err = writeQualityReport(ctx, rp, reportFormat, profile.Compression, qualityReportStream{export.stream(stream)})
+	}
+	if err != nil {
+		if ctxErr := s.contextError(ctx); ctxErr != nil {
+			err = ctxErr
+		}
+		logger.FromContext(ctx).Error("failed to send export", zap.Error(err))
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	access.RowCount = len(rp.GetResponses())
+	export.rows = access.RowCount
+	s.saveDataAccessLog(ctx, access, nil, false)
+	return nil
+}
+
+func (s *dataServiceServer) GetDataQualityReport(req *api.ResponseQuery, stream api.DataServiceApi_GetDataQualityReportServer) (err error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" {
+		return status.Error(codes.InvalidArgument, "missing argument")
+	}
+	export := newExportObserver(req.StudyKey, req.SurveyKey)
+	defer func() { export.done(err) }()
+	ctx, cancel := s.exportContext(stream.Context())
+	defer cancel()
+
+	if req.DuplicateWindowMinutes < 0 {
+		return status.Error(codes.InvalidArgument, "duplicate window must not be negative")
+	}
+	format, err := checkReportFormat(req.ReportFormat)
+	if err != nil {
+		return err
+	}
+	if err := s.checkTimeRange(req.From, req.Until); err != nil {
+		return err
+	}
+
+	sel := responseSelection{
+		Token:           req.Token,
+		StudyKey:        req.StudyKey,
+		SurveyKey:       req.SurveyKey,
+		From:            req.From,
+		Until:           req.Until,
+		DuplicateWindow: time.Duration(req.DuplicateWindowMinutes) * time.Minute,
+		MaxResponses:    s.limits.MaxResponses,
+		ProfileName:     req.Profile,
+	}
+	// the report leaves out the columns that the profile removes from the export
+	profile, err := s.exportProfile(ctx, req, sel.dataAccess())
+	if err != nil {
+		return err
+	}
+	if err := checkCompression(profile.Compression); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	export.format = exportFormat("quality-report-"+format, profile.Compression)
+	sel.Format = export.format
+	sel.ShortQuestionKeys = profile.ShortQuestionKeys
+	sel.Separator = profile.Separator
+	sel.TimestampFormat = profile.TimestampFormat
+	sel.Profile = &profile
+
+	restrictions, err := s.checkExportPolicy(ctx, sel.dataAccess())
+	if err != nil {
+		return err
+	}
+	sel.Ticket, err = s.admitExport(ctx, req.Token, req.StudyKey, stream)
+	if err != nil {
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return err
+	}
+	defer sel.Ticket.Release()
+
+	rp, err := s.parseStudyResponses(ctx, sel, restrictions)
+	if err != nil {
+		return err
+	}
+	access := sel.dataAccess()
+
+	err = writeQualityReport(ctx, rp, format, profile.Compression, export.stream(stream))
+	if err != nil {
+		if ctxErr := s.contextError(ctx); ctxErr != nil {
+			err = ctxErr
+		} else {
+			err = status.Error(codes.Internal, err.Error())
+		}
+		logger.FromContext(ctx).Error("failed to send report", zap.Error(err))
+		s.saveDataAccessLog(ctx, access, err, false)
+		return err
+	}
+	access.RowCount = len(rp.GetResponses())
+	export.rows = access.RowCount
+	s.saveDataAccessLog(ctx, access, nil, false)
+	return nil
+}
+
+// checkReportFormat returns the format of the data-quality report, JSON by default
+func checkReportFormat(format string) (string, error) {
+	switch format {
+	case "":
+		return response_parser.REPORT_FORMAT_JSON, nil
+	case response_parser.REPORT_FORMAT_JSON, response_parser.REPORT_FORMAT_CSV:
+		return format, nil
+	}
+	return "", status.Error(codes.InvalidArgument, "unknown report format")
+}
+
+// writeQualityReport sends the data-quality report of the parsed responses as chunks
+func writeQualityReport(ctx context.Context, rp *response_parser.ResponseParser, format string, compression string, stream chunkStream) error {
+	w, err := newChunkWriter(ctx, compression, stream)
+	if err != nil {
+		return err
+	}
+	if format == response_parser.REPORT_FORMAT_CSV {
+		err = rp.GetDataQualityReportCSV(w)
+	} else {
+		err = rp.GetDataQualityReportJSON(w)
+	}
 	if err != nil {
-		log.Printf("GetResponsesCSV: %v", err)
+		w.Abort()
 		return err
 	}
+	return w.Close()
+}
 
+// qualityReportStream sends the data of the chunks as quality report, so clients can tell it apart from
+// the export
+type qualityReportStream struct {
+	chunkStream
+}
+
+func (s qualityReportStream) Send(chnk *api.Chunk) error {
+	return s.chunkStream.Send(&api.Chunk{QualityReport: chnk.Chunk})
+}
+
+type chunkStream interface {
+	Send(*api.Chunk) error
+}
+
+// sendChunks splits the data into chunks of at most chunkSize bytes
+func sendChunks(data []byte, stream chunkStream) error {
 	chnk := &api.Chunk{}
 
-	for currentByte := 0; currentByte < len(buf.Bytes()); currentByte += chunkSize {
-		if currentByte+chunkSize > len(buf.Bytes()) {
-			chnk.Chunk = buf.Bytes()[currentByte:len(buf.Bytes())]
+	for currentByte := 0; currentByte < len(data); currentByte += chunkSize {
+		if currentByte+chunkSize > len(data) {
+			chnk.Chunk = data[currentByte:]
 		} else {
-			chnk.Chunk = buf.Bytes()[currentByte : currentByte+chunkSize]
+			chnk.Chunk = data[currentByte : currentByte+chunkSize]
 		}
 
 		if err := stream.Send(chnk); err != nil {
@@ -87,9 +330,89 @@ func (s *dataServiceServer) GetResponsesCSV(req *api.ResponseQuery, stream api.D
 }
 
 func (s *dataServiceServer) GetSurveyInfoCSV(req *api.SurveyInfoQuery, stream api.DataServiceApi_GetSurveyInfoCSVServer) error {
+	ctx := stream.Context()
+	rp, err := s.getSurveyInfoParser(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	if err := rp.SetCSVDialect(response_parser.CSVDialectFromAPI(req.CsvDialect)); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	access := surveyInfoAccess(req, rp, "csv")
+	buf := new(bytes.Buffer)
+	err = rp.GetSurveyInfoCSV(buf)
+	if err == nil {
+		err = sendChunks(buf.Bytes(), stream)
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to send survey info", zap.Error(err))
+		s.saveDataAccessLog(ctx, access, err, false)
+		return status.Error(codes.Internal, err.Error())
+	}
+	s.saveDataAccessLog(ctx, access, nil, false)
 	return nil
 }
 
 func (s *dataServiceServer) GetSurveyInfo(ctx context.Context, req *api.SurveyInfoQuery) (*api.SurveyInfo, error) {
-	return nil, nil
+	rp, err := s.getSurveyInfoParser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := rp.GetSurveyVersionDefs()
+	resp := &api.SurveyInfo{
+		Key:      req.SurveyKey,
+		Versions: make([]*api.SurveyVersionPreview, len(versions)),
+	}
+	for i, v := range versions {
+		resp.Versions[i] = v.ToAPI()
+	}
+	s.saveDataAccessLog(ctx, surveyInfoAccess(req, rp, ""), nil, false)
+	return resp, nil
+}
+
+// getSurveyInfoParser prepares a response parser for the codebook of a survey, no responses are added
+func (s *dataServiceServer) getSurveyInfoParser(ctx context.Context, req *api.SurveyInfoQuery) (*response_parser.ResponseParser, error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" || req.SurveyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	access := dataAccess{
+		Token:     req.Token,
+		EventName: constants.LOG_EVENT_GET_SURVEY_DEF,
+		StudyKey:  req.StudyKey,
+		SurveyKey: req.SurveyKey,
+	}
+	// the codebook contains no participant data, so restrictions do not apply
+	if _, err := s.checkExportPolicy(ctx, access); err != nil {
+		return nil, err
+	}
+
+	surveyDef, err := s.getSurveyDef(ctx, req.Token, req.StudyKey, req.SurveyKey)
+	if err != nil {
+		s.saveDataAccessLog(ctx, access, err, true)
+		return nil, err
+	}
+
+	rp, err := response_parser.NewResponseParser(surveyDef, req.PreviewLanguage, req.ShortQuestionKeys, "-", logger.FromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return rp, nil
+}
+
+// surveyInfoAccess describes a survey info request for the audit log, the rows are the questions of all versions
+func surveyInfoAccess(req *api.SurveyInfoQuery, rp *response_parser.ResponseParser, format string) dataAccess {
+	access := dataAccess{
+		Token:     req.Token,
+		EventName: constants.LOG_EVENT_GET_SURVEY_DEF,
+		StudyKey:  req.StudyKey,
+		SurveyKey: req.SurveyKey,
+		Format:    format,
+	}
+	for _, v := range rp.GetSurveyVersionDefs() {
+		access.RowCount += len(v.Questions)
+	}
+	return access
 }
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_profiles"
+	"github.com/influenzanet/data-service/pkg/response_parser"
+	"github.com/influenzanet/data-service/pkg/types"
+	studyMock "github.com/influenzanet/data-service/test/mocks/study-service"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// mockResponseStream returns the responses of the study service one by one
+type mockResponseStream struct {
+	grpc.ClientStream
+	responses []*studyAPI.SurveyResponse
+}
+
+func (m *mockResponseStream) Recv() (*studyAPI.SurveyResponse, error) {
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	r := m.responses[0]
+	m.responses = m.responses[1:]
+	return r, nil
+}
+
+// mockExportStream collects the chunks sent to the client. The zstd encoder sends from its own goroutine.
+type mockExportStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	mu     sync.Mutex
+	chunks []*api.Chunk
+}
+
+func (m *mockExportStream) Send(chnk *api.Chunk) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.chunks = append(m.chunks, chnk)
+	return nil
+}
+
+func (m *mockExportStream) sent() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.chunks)
+}
+
+func (m *mockExportStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockExportStream) data() (export string, report string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.chunks {
+		export += string(c.Chunk)
+		report += string(c.QualityReport)
+	}
+	return export, report
+}
+
+var testToken = &api_types.TokenInfos{Id: "user1", InstanceId: "test"}
+
+func mockSurvey() *studyAPI.Survey {
+	return &studyAPI.Survey{
+		Current: &studyAPI.SurveyVersion{
+			Published: 10,
+			VersionId: "1",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key: "weekly",
+				Items: []*studyAPI.SurveyItem{
+					{Key: "weekly.Q1", Components: &studyAPI.ItemComponent{Role: "root", Items: []*studyAPI.ItemComponent{
+						{Key: "rg", Role: "responseGroup", Items: []*studyAPI.ItemComponent{
+							{Key: "scg", Role: "singleChoiceGroup", Items: []*studyAPI.ItemComponent{
+								{Key: "yes", Role: "option"},
+								{Key: "no", Role: "option"},
+							}},
+						}},
+					}}},
+				},
+			},
+		},
+	}
+}
+
+func mockResponse(pID string, submittedAt int64, answer string) *studyAPI.SurveyResponse {
+	return &studyAPI.SurveyResponse{Key: "weekly", ParticipantId: pID, SubmittedAt: submittedAt, VersionId: "1",
+		Responses: []*studyAPI.SurveyItemResponse{
+			{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: answer}}},
+			}}},
+		},
+	}
+}
+
+// newTestServer returns a server whose study service returns the survey and responses
+func newTestServer(mockCtrl *gomock.Controller, responses ...*studyAPI.SurveyResponse) (*dataServiceServer, *studyMock.MockStudyServiceApiClient) {
+	studyClient := studyMock.NewMockStudyServiceApiClient(mockCtrl)
+	studyClient.EXPECT().GetSurveyDefForStudy(gomock.Any(), gomock.Any()).Return(mockSurvey(), nil).AnyTimes()
+	studyClient.EXPECT().StreamStudyResponses(gomock.Any(), gomock.Any()).Return(&mockResponseStream{responses: responses}, nil).AnyTimes()
+	s := newDataServiceServer(&types.APIClients{StudyService: studyClient}, config.Config{}, zap.NewNop())
+	return s, studyClient
+}
+
+func TestGetResponsesCSV(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	t.Run("with quality report", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl,
+			mockResponse("p1", 1000, "yes"),
+			mockResponse("p1", 1060, "maybe"),
+		)
+		stream := &mockExportStream{ctx: context.Background()}
+		err := s.GetResponsesCSV(&api.ResponseQuery{
+			Token:                  testToken,
+			StudyKey:               "study1",
+			SurveyKey:              "weekly",
+			ShortQuestionKeys:      true,
+			DuplicateWindowMinutes: 5,
+			IncludeQualityReport:   true,
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		last := stream.chunks[len(stream.chunks)-1]
+		if len(last.QualityReport) == 0 || len(last.Chunk) != 0 {
+			t.Error("report expected after the export")
+		}
+		export, report := stream.data()
+		if lines := strings.Split(strings.TrimSpace(export), "\n"); len(lines) != 3 {
+			t.Errorf("unexpected export: %s", export)
+		}
+		var parsed response_parser.DataQualityReport
+		if err := json.Unmarshal([]byte(report), &parsed); err != nil {
+			t.Fatalf("unexpected report: %v", err)
+		}
+		if parsed.IssueCounts[response_parser.QUALITY_ISSUE_DUPLICATE] != 1 || parsed.IssueCounts[response_parser.QUALITY_ISSUE_UNKNOWN_KEY] != 1 {
+			t.Errorf("unexpected issue counts: %v", parsed.IssueCounts)
+		}
+	})
+
+	t.Run("without quality report", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		stream := &mockExportStream{ctx: context.Background()}
+		err := s.GetResponsesCSV(&api.ResponseQuery{
+			Token:     testToken,
+			StudyKey:  "study1",
+			SurveyKey: "weekly",
+		}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, report := stream.data(); report != "" {
+			t.Errorf("unexpected report: %s", report)
+		}
+	})
+
+	t.Run("with quality report of a profile", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "secret-option"))
+		s.profiles = export_profiles.Profiles{"restricted": {ShortQuestionKeys: true, ExcludeColumns: []string{"Q1*"}}}
+		query := &api.ResponseQuery{
+			Token:                testToken,
+			StudyKey:             "study1",
+			SurveyKey:            "weekly",
+			Profile:              "restricted",
+			IncludeQualityReport: true,
+		}
+		stream := &mockExportStream{ctx: context.Background()}
+		if err := s.GetResponsesCSV(query, stream); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		reportStream := &mockExportStream{ctx: context.Background()}
+		if err := s.GetDataQualityReport(query, reportStream); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, report := stream.data()
+		// the report endpoint sends the report as data
+		standalone, _ := reportStream.data()
+		for _, r := range []string{report, standalone} {
+			if r == "" || strings.Contains(r, "secret-option") {
+				t.Errorf("unexpected report: %s", r)
+			}
+		}
+	})
+
+	t.Run("with unknown report format", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl)
+		stream := &mockExportStream{ctx: context.Background()}
+		err := s.GetResponsesCSV(&api.ResponseQuery{
+			Token:                testToken,
+			StudyKey:             "study1",
+			IncludeQualityReport: true,
+			ReportFormat:         "xml",
+		}, stream)
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+}
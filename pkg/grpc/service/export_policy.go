@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/response_parser"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// checkExportPolicy returns the restrictions for the user, denied requests are written to the audit log
+func (s *dataServiceServer) checkExportPolicy(ctx context.Context, access dataAccess) (export_policy.Restrictions, error) {
+	restrictions, err := s.policy.Check(access.Token, access.StudyKey)
+	if err != nil {
+		s.saveDataAccessLog(ctx, access, err, true)
+		return restrictions, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return restrictions, nil
+}
+
+// applyRestrictions has to be called before adding responses
+func (s *dataServiceServer) applyRestrictions(rp *response_parser.ResponseParser, restrictions export_policy.Restrictions) {
+	if restrictions.Pseudonymised {
+		rp.SetPseudonymisation(s.policy.PseudonymisationKey)
+	}
+	rp.SetExcludeFreeText(restrictions.NoFreeText)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/export_profiles"
+	"github.com/influenzanet/data-service/pkg/response_parser"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// profileFromRequest collects the format options of a request without profile
+func profileFromRequest(req *api.ResponseQuery) export_profiles.Profile {
+	return export_profiles.Profile{
+		ShortQuestionKeys: req.ShortQuestionKeys,
+		Separator:         req.Separator,
+		IncludeMeta:       req.IncludeMeta,
+		IncludeTiming:     req.IncludeTiming,
+		Compression:       req.Compression,
+		TimestampFormat:   response_parser.TimestampFormatFromAPI(req.TimestampFormat),
+		BooleanEncoding:   response_parser.BooleanEncodingFromAPI(req.BooleanEncoding),
+		ArrayEncoding:     response_parser.ArrayEncodingFromAPI(req.ArrayEncoding),
+		CSVDialect:        response_parser.CSVDialectFromAPI(req.CsvDialect),
+		MissingValues:     response_parser.MissingValueCodingFromAPI(req.MissingValues),
+	}
+}
+
+// exportProfile returns the profile referenced by the request or the options of the request itself
+func (s *dataServiceServer) exportProfile(ctx context.Context, req *api.ResponseQuery, access dataAccess) (export_profiles.Profile, error) {
+	if req.Profile == "" {
+		return profileFromRequest(req), nil
+	}
+	profile, err := s.profiles.Get(req.Profile, req.Token)
+	switch err {
+	case nil:
+		return profile, nil
+	case export_profiles.ErrUnknownProfile:
+		return profile, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.saveDataAccessLog(ctx, access, err, true)
+		return profile, status.Error(codes.PermissionDenied, err.Error())
+	}
+}
+
+// applyProfile configures the parser for the profile, it has to be called before adding responses
+func (s *dataServiceServer) applyProfile(rp *response_parser.ResponseParser, profile export_profiles.Profile, restrictions export_policy.Restrictions) error {
+	restrictions.Pseudonymised = restrictions.Pseudonymised || profile.Pseudonymised
+	restrictions.NoFreeText = restrictions.NoFreeText || profile.NoFreeText
+	s.applyRestrictions(rp, restrictions)
+
+	rp.SetOptionLabels(profile.Language != "")
+	rp.SetIncludeTiming(profile.IncludeTiming)
+	if err := rp.SetColumnFilter(profile.Columns, profile.ExcludeColumns); err != nil {
+		return err
+	}
+	if err := rp.SetTimestampFormat(profile.TimestampFormat); err != nil {
+		return err
+	}
+	if err := rp.SetBooleanEncoding(profile.BooleanEncoding); err != nil {
+		return err
+	}
+	rp.SetArrayEncoding(profile.ArrayEncoding)
+	if err := rp.SetCSVDialect(profile.CSVDialect); err != nil {
+		return err
+	}
+	rp.SetMissingValueCoding(profile.MissingValues)
+	return nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/types"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// serviceName is the name of the data service in the gRPC health protocol
+	serviceName = "influenzanet.data_service.DataServiceApi"
+
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 2 * time.Second
+)
+
+// healthChecker probes the study and logging service and reports the result through the gRPC health
+// protocol. Without the study service no data can be exported, so the service is not serving. A
+// missing logging service is reported as degraded.
+type healthChecker struct {
+	clients  *types.APIClients
+	server   *health.Server
+	logger   *zap.Logger
+	interval time.Duration
+	timeout  time.Duration
+
+	mu       sync.RWMutex
+	problems []string
+}
+
+func newHealthChecker(clients *types.APIClients, conf config.HealthCheck, logger *zap.Logger) *healthChecker {
+	h := &healthChecker{
+		clients:  clients,
+		server:   health.NewServer(),
+		logger:   logger,
+		interval: conf.Interval.Duration,
+		timeout:  conf.Timeout.Duration,
+		problems: []string{"dependencies not checked yet"},
+	}
+	if h.interval <= 0 {
+		h.interval = defaultHealthCheckInterval
+	}
+	if h.timeout <= 0 {
+		h.timeout = defaultHealthCheckTimeout
+	}
+	h.setServingStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+	return h
+}
+
+// run checks the dependencies until ctx is done
+func (h *healthChecker) run(ctx context.Context) {
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+	for {
+		h.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *healthChecker) check(ctx context.Context) {
+	problems := []string{}
+	serving := healthpb.HealthCheckResponse_SERVING
+
+	if err := probe(ctx, h.timeout, h.clients.StudyServiceConn, h.studyServiceStatus); err != nil {
+		problems = append(problems, "study service: "+err.Error())
+		serving = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	if err := probe(ctx, h.timeout, h.clients.LoggingServiceConn, h.loggingServiceStatus); err != nil {
+		problems = append(problems, "logging service: "+err.Error())
+	}
+
+	h.mu.Lock()
+	changed := strings.Join(h.problems, "; ") != strings.Join(problems, "; ")
+	h.problems = problems
+	h.mu.Unlock()
+
+	if changed {
+		if len(problems) > 0 {
+			h.logger.Warn("dependencies unavailable", zap.Strings("problems", problems))
+		} else {
+			h.logger.Info("all dependencies available")
+		}
+	}
+	h.setServingStatus(serving)
+}
+
+func (h *healthChecker) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	h.server.SetServingStatus("", status)
+	h.server.SetServingStatus(serviceName, status)
+}
+
+// status is the result of the last check for the Status endpoint
+func (h *healthChecker) status() (api_types.ServiceStatus_StatusValue, string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.problems) == 0 {
+		return api_types.ServiceStatus_NORMAL, "service running"
+	}
+	return api_types.ServiceStatus_PROBLEM, "service degraded: " + strings.Join(h.problems, "; ")
+}
+
+// shutdown reports all services as not serving
+func (h *healthChecker) shutdown() {
+	h.server.Shutdown()
+}
+
+func (h *healthChecker) studyServiceStatus(ctx context.Context) error {
+	if h.clients.StudyService == nil {
+		return fmt.Errorf("not connected")
+	}
+	resp, err := h.clients.StudyService.Status(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	if resp.Status != studyAPI.ServiceStatus_NORMAL {
+		return fmt.Errorf("status %s: %s", resp.Status, resp.Msg)
+	}
+	return nil
+}
+
+func (h *healthChecker) loggingServiceStatus(ctx context.Context) error {
+	if h.clients.LoggingService == nil {
+		return fmt.Errorf("not connected")
+	}
+	resp, err := h.clients.LoggingService.Status(ctx, &empty.Empty{})
+	if err != nil {
+		return err
+	}
+	if resp.Status != api_types.ServiceStatus_NORMAL {
+		return fmt.Errorf("status %s: %s", resp.Status, resp.Msg)
+	}
+	return nil
+}
+
+// probe fails fast if the connection is broken, otherwise the status call decides
+func probe(ctx context.Context, timeout time.Duration, conn *grpc.ClientConn, statusCall func(context.Context) error) error {
+	if conn != nil {
+		switch state := conn.GetState(); state {
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return fmt.Errorf("connection state %s", state)
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return statusCall(ctx)
+}
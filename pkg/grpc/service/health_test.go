@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/types"
+	loggingMock "github.com/influenzanet/data-service/test/mocks/logging_service"
+	studyMock "github.com/influenzanet/data-service/test/mocks/study-service"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestHealthChecker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	studyClient := studyMock.NewMockStudyServiceApiClient(mockCtrl)
+	loggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	h := newHealthChecker(&types.APIClients{
+		StudyService:   studyClient,
+		LoggingService: loggingClient,
+	}, config.HealthCheck{}, zap.NewNop())
+
+	servingStatus := func() healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := h.server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: serviceName})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp.Status
+	}
+
+	t.Run("before first check", func(t *testing.T) {
+		if s := servingStatus(); s != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("unexpected serving status: %s", s)
+		}
+	})
+
+	t.Run("all dependencies available", func(t *testing.T) {
+		studyClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&studyAPI.ServiceStatus{Status: studyAPI.ServiceStatus_NORMAL}, nil)
+		loggingClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&api_types.ServiceStatus{Status: api_types.ServiceStatus_NORMAL}, nil)
+		h.check(context.Background())
+		if s := servingStatus(); s != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("unexpected serving status: %s", s)
+		}
+		if s, msg := h.status(); s != api_types.ServiceStatus_NORMAL {
+			t.Errorf("unexpected status: %s %s", s, msg)
+		}
+	})
+
+	t.Run("logging service down", func(t *testing.T) {
+		studyClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&studyAPI.ServiceStatus{Status: studyAPI.ServiceStatus_NORMAL}, nil)
+		loggingClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(nil, errors.New("unavailable"))
+		h.check(context.Background())
+		if s := servingStatus(); s != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("unexpected serving status: %s", s)
+		}
+		if s, msg := h.status(); s != api_types.ServiceStatus_PROBLEM || msg != "service degraded: logging service: unavailable" {
+			t.Errorf("unexpected status: %s %s", s, msg)
+		}
+	})
+
+	t.Run("study service reports problem", func(t *testing.T) {
+		studyClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&studyAPI.ServiceStatus{Status: studyAPI.ServiceStatus_PROBLEM, Msg: "db"}, nil)
+		loggingClient.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&api_types.ServiceStatus{Status: api_types.ServiceStatus_NORMAL}, nil)
+		h.check(context.Background())
+		if s := servingStatus(); s != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("unexpected serving status: %s", s)
+		}
+		if s, _ := h.status(); s != api_types.ServiceStatus_PROBLEM {
+			t.Errorf("unexpected status: %s", s)
+		}
+	})
+}
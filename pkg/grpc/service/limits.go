@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// getSurveyDef fetches the survey definition within the configured request timeout
+func (s *dataServiceServer) getSurveyDef(ctx context.Context, token *api_types.TokenInfos, studyKey string, surveyKey string) (*studyAPI.Survey, error) {
+	if s.timeouts.ServiceRequest.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeouts.ServiceRequest.Duration)
+		defer cancel()
+	}
+	return s.clients.StudyService.GetSurveyDefForStudy(ctx, &studyAPI.SurveyReferenceRequest{
+		Token:     token,
+		StudyKey:  studyKey,
+		SurveyKey: surveyKey,
+	})
+}
+
+// checkTimeRange enforces the maximum time range of an export
+func (s *dataServiceServer) checkTimeRange(from int64, until int64) error {
+	maxRange := s.limits.MaxTimeRange.Duration
+	if maxRange == 0 {
+		return nil
+	}
+	if from <= 0 || until <= 0 {
+		return status.Errorf(codes.InvalidArgument, "from and until are required, the time range is limited to %s", maxRange)
+	}
+	if time.Duration(until-from)*time.Second > maxRange {
+		return status.Errorf(codes.InvalidArgument, "time range exceeds the limit of %s", maxRange)
+	}
+	return nil
+}
+
+// checkResponseCount is called for each received response, maxResponses 0 means no limit
+func checkResponseCount(count int, maxResponses int) error {
+	if maxResponses > 0 && count > maxResponses {
+		return status.Errorf(codes.ResourceExhausted, "more than %d responses, please select a shorter time range", maxResponses)
+	}
+	return nil
+}
+
+type exportDeadlineKey struct{}
+
+// exportContext limits the duration of an export to the configured maximum. The context also ends when the
+// client cancels the request.
+func (s *dataServiceServer) exportContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	maxDuration := s.limits.MaxDuration.Duration
+	if maxDuration <= 0 {
+		return context.WithCancel(ctx)
+	}
+	deadline := time.Now().Add(maxDuration)
+	return context.WithDeadline(context.WithValue(ctx, exportDeadlineKey{}, deadline), deadline)
+}
+
+// contextError converts the end of the request context into a status error, it is nil while the request
+// is running
+func (s *dataServiceServer) contextError(ctx context.Context) error {
+	switch ctx.Err() {
+	case nil:
+		return nil
+	case context.DeadlineExceeded:
+		if deadline, ok := ctx.Value(exportDeadlineKey{}).(time.Time); ok && !time.Now().Before(deadline) {
+			return status.Errorf(codes.DeadlineExceeded, "export exceeded the maximum duration of %s, please select a shorter time range", s.limits.MaxDuration)
+		}
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	default:
+		return status.Error(codes.Canceled, "request cancelled")
+	}
+}
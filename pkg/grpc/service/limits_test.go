@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/admission"
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockChunkStream struct {
+	chunks int
+}
+
+func (m *mockChunkStream) Send(*api.Chunk) error {
+	m.chunks++
+	return nil
+}
+
+func TestExportContext(t *testing.T) {
+	t.Run("maximum duration exceeded", func(t *testing.T) {
+		s := &dataServiceServer{limits: config.ExportLimits{MaxDuration: config.Duration{Duration: time.Millisecond}}}
+		ctx, cancel := s.exportContext(context.Background())
+		defer cancel()
+		<-ctx.Done()
+		if err := s.contextError(ctx); status.Code(err) != codes.DeadlineExceeded {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("cancelled by client", func(t *testing.T) {
+		s := &dataServiceServer{}
+		parent, cancelParent := context.WithCancel(context.Background())
+		ctx, cancel := s.exportContext(parent)
+		defer cancel()
+		if err := s.contextError(ctx); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cancelParent()
+		if err := s.contextError(ctx); status.Code(err) != codes.Canceled {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("nothing sent after cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &mockChunkStream{}
+		w, err := newChunkWriter(ctx, compressionNone, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := w.Write(make([]byte, chunkSize+1)); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		cancel()
+		if err := w.Close(); err == nil {
+			t.Error("close after cancel should fail with error")
+		}
+		if stream.chunks != 1 {
+			t.Errorf("unexpected number of chunks: %d", stream.chunks)
+		}
+	})
+}
+
+func TestStatisticsLimits(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	query := &api.ResponseStatisticsQuery{
+		Token:     testToken,
+		StudyKey:  "study1",
+		SurveyKey: "weekly",
+		From:      100,
+		Until:     2000,
+	}
+
+	t.Run("time range exceeded", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		s.limits.MaxTimeRange = config.Duration{Duration: time.Minute}
+		_, err := s.GetResponseStatistics(context.Background(), query)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("maximum duration exceeded", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		s.limits.MaxDuration = config.Duration{Duration: time.Nanosecond}
+		_, err := s.GetResponseStatistics(context.Background(), query)
+		if status.Code(err) != codes.DeadlineExceeded {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("too many responses", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"), mockResponse("p2", 1100, "no"))
+		s.limits.MaxResponses = 1
+		_, err := s.GetResponseStatistics(context.Background(), query)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("no slot free", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		s.admission = admission.NewController(admission.Limits{MaxPerUser: 1}, nil)
+		s.maxQueueWait = time.Millisecond
+		ticket, err := s.admission.Acquire(context.Background(), testToken.InstanceId+"/"+testToken.Id, "test/study1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = s.GetResponseStatistics(context.Background(), query)
+		if status.Code(err) != codes.ResourceExhausted {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		ticket.Release()
+		if _, err := s.GetResponseStatistics(context.Background(), query); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("denied before admission", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl)
+		s.policy = export_policy.Policy{Rules: []export_policy.Rule{{Name: "researchers", Roles: []string{"RESEARCHER"}}}}
+		s.admission = admission.NewController(admission.Limits{MaxPerUser: 1}, nil)
+		s.maxQueueWait = time.Millisecond
+		ticket, err := s.admission.Acquire(context.Background(), testToken.InstanceId+"/"+testToken.Id, "test/study1", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer ticket.Release()
+		_, err = s.GetResponseStatistics(context.Background(), query)
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influenzanet/data-service/pkg/logger"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"go.uber.org/zap"
+)
+
+// defaultLogEventTimeout is used without a configured service request timeout, so an unreachable logging
+// service cannot stall the requests
+const defaultLogEventTimeout = 5 * time.Second
+
+// SaveLogEvent sends the event to the logging service. The event is saved even if the request ended, ctx
+// is only used for its request ID.
+func (s *dataServiceServer) SaveLogEvent(
+	ctx context.Context,
+	instanceID string,
+	userID string,
+	eventType loggingAPI.LogEventType,
+	eventName string,
+	msg string,
+) {
+	if s.clients.LoggingService == nil {
+		return
+	}
+	timeout := s.timeouts.ServiceRequest.Duration
+	if timeout <= 0 {
+		timeout = defaultLogEventTimeout
+	}
+	logCtx, cancel := context.WithTimeout(detachedContext{ctx}, timeout)
+	defer cancel()
+	_, err := s.clients.LoggingService.SaveLogEvent(logCtx, &loggingAPI.NewLogEvent{
+		Origin:     "data-service",
+		InstanceId: instanceID,
+		UserId:     userID,
+		EventType:  eventType,
+		EventName:  eventName,
+		Msg:        msg,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("failed to save log event", zap.String("eventName", eventName), zap.Error(err))
+	}
+}
+
+// detachedContext keeps the values of a request context, like the request ID, but not its cancellation
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+// dataAccess describes a request for participant data for the audit log
+type dataAccess struct {
+	Token     *api_types.TokenInfos
+	EventName string
+	StudyKey  string
+	SurveyKey string
+	From      int64
+	Until     int64
+	Format    string
+	Profile   string
+	RowCount  int
+}
+
+// saveDataAccessLog records who accessed which data and the outcome. Errors from the study service
+// (missing permissions or unknown study) are logged as security events.
+func (s *dataServiceServer) saveDataAccessLog(ctx context.Context, da dataAccess, err error, accessDenied bool) {
+	if da.Token == nil {
+		return
+	}
+	msg := []string{
+		"study: " + da.StudyKey,
+		"survey: " + da.SurveyKey,
+	}
+	if da.From > 0 || da.Until > 0 {
+		msg = append(msg, fmt.Sprintf("from: %d, until: %d", da.From, da.Until))
+	}
+	if da.Format != "" {
+		msg = append(msg, "format: "+da.Format)
+	}
+	if da.Profile != "" {
+		msg = append(msg, "profile: "+da.Profile)
+	}
+
+	eventType := loggingAPI.LogEventType_LOG
+	switch {
+	case err == nil:
+		msg = append(msg, fmt.Sprintf("rows: %d", da.RowCount), "outcome: success")
+	case accessDenied:
+		eventType = loggingAPI.LogEventType_SECURITY
+		msg = append(msg, "outcome: access failed: "+err.Error())
+	default:
+		eventType = loggingAPI.LogEventType_ERROR
+		msg = append(msg, "outcome: failed: "+err.Error())
+	}
+	s.SaveLogEvent(ctx, da.Token.InstanceId, da.Token.Id, eventType, da.EventName, strings.Join(msg, ", "))
+}
+
+// exportFormat is the format of an export as written to the audit log
+func exportFormat(format string, compression string) string {
+	if compression == compressionNone {
+		return format
+	}
+	return format + "+" + compression
+}
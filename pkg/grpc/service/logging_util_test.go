@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/types"
+	loggingMock "github.com/influenzanet/data-service/test/mocks/logging_service"
+	studyMock "github.com/influenzanet/data-service/test/mocks/study-service"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/go-utils/pkg/constants"
+	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// expectLogEvent records the events sent to the logging service, the context of the call must not be done
+func expectLogEvent(t *testing.T, mockCtrl *gomock.Controller) (*loggingMock.MockLoggingServiceApiClient, *[]*loggingAPI.NewLogEvent) {
+	events := []*loggingAPI.NewLogEvent{}
+	loggingClient := loggingMock.NewMockLoggingServiceApiClient(mockCtrl)
+	loggingClient.EXPECT().SaveLogEvent(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, event *loggingAPI.NewLogEvent, opts ...interface{}) (*api_types.ServiceStatus, error) {
+			if err := ctx.Err(); err != nil {
+				t.Errorf("log event sent with ended context: %v", err)
+			}
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("log event sent without timeout")
+			}
+			events = append(events, event)
+			return &api_types.ServiceStatus{}, nil
+		}).AnyTimes()
+	return loggingClient, &events
+}
+
+func checkLogEvent(t *testing.T, events []*loggingAPI.NewLogEvent, eventType loggingAPI.LogEventType, msgParts ...string) {
+	t.Helper()
+	if len(events) != 1 {
+		t.Fatalf("unexpected number of log events: %d", len(events))
+	}
+	e := events[0]
+	if e.EventType != eventType || e.EventName != constants.LOG_EVENT_DOWNLOAD_RESPONSES ||
+		e.InstanceId != testToken.InstanceId || e.UserId != testToken.Id {
+		t.Errorf("unexpected log event: %v", e)
+	}
+	for _, part := range msgParts {
+		if !strings.Contains(e.Msg, part) {
+			t.Errorf("%q missing in message: %s", part, e.Msg)
+		}
+	}
+}
+
+func TestDataAccessLog(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	query := &api.ResponseQuery{
+		Token:     testToken,
+		StudyKey:  "study1",
+		SurveyKey: "weekly",
+		From:      100,
+		Until:     2000,
+	}
+
+	t.Run("successful export", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"), mockResponse("p2", 1100, "no"))
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s.clients.LoggingService = loggingClient
+		if err := s.GetResponsesCSV(query, &mockExportStream{ctx: context.Background()}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_LOG,
+			"study: study1", "survey: weekly", "from: 100, until: 2000", "format: csv", "rows: 2", "outcome: success")
+	})
+
+	t.Run("successful statistics", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s.clients.LoggingService = loggingClient
+		_, err := s.GetResponseStatistics(context.Background(), &api.ResponseStatisticsQuery{
+			Token:          testToken,
+			StudyKey:       "study1",
+			SurveyKey:      "weekly",
+			From:           100,
+			Until:          2000,
+			ParticipantIds: []string{"p1"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_LOG,
+			"study: study1", "survey: weekly", "from: 100, until: 2000", "format: statistics", "rows: 1", "outcome: success")
+	})
+
+	t.Run("denied by policy", func(t *testing.T) {
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s := newDataServiceServer(&types.APIClients{
+			StudyService:   studyMock.NewMockStudyServiceApiClient(mockCtrl),
+			LoggingService: loggingClient,
+		}, config.Config{ExportPolicy: export_policy.Policy{Rules: []export_policy.Rule{
+			{Name: "researchers", Roles: []string{"RESEARCHER"}},
+		}}}, zap.NewNop())
+		err := s.GetResponsesCSV(query, &mockExportStream{ctx: context.Background()})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_SECURITY,
+			"study: study1", "survey: weekly", "from: 100, until: 2000", "format: csv", "outcome: access failed: permission denied")
+	})
+
+	t.Run("participants selected from pseudonymised data", func(t *testing.T) {
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s := newDataServiceServer(&types.APIClients{
+			StudyService:   studyMock.NewMockStudyServiceApiClient(mockCtrl),
+			LoggingService: loggingClient,
+		}, config.Config{ExportPolicy: export_policy.Policy{
+			PseudonymisationKey: "secret",
+			Rules: []export_policy.Rule{
+				{Name: "analysts", Claims: map[string]string{"group": "analysts"}, Restrictions: export_policy.Restrictions{Pseudonymised: true}},
+			},
+		}}, zap.NewNop())
+		analyst := &api_types.TokenInfos{Id: testToken.Id, InstanceId: testToken.InstanceId, Payload: map[string]string{"group": "analysts"}}
+		_, err := s.GetResponseStatistics(context.Background(), &api.ResponseStatisticsQuery{
+			Token:          analyst,
+			StudyKey:       "study1",
+			SurveyKey:      "weekly",
+			ParticipantIds: []string{"p1"},
+		})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_SECURITY, "format: statistics", "outcome: access failed")
+	})
+
+	t.Run("error of the study service", func(t *testing.T) {
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		studyClient := studyMock.NewMockStudyServiceApiClient(mockCtrl)
+		studyClient.EXPECT().GetSurveyDefForStudy(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+		s := newDataServiceServer(&types.APIClients{
+			StudyService:   studyClient,
+			LoggingService: loggingClient,
+		}, config.Config{}, zap.NewNop())
+		err := s.GetResponsesCSV(query, &mockExportStream{ctx: context.Background()})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_SECURITY,
+			"study: study1", "survey: weekly", "from: 100, until: 2000", "format: csv", "outcome: access failed: rpc error: code = Unavailable desc = connection refused")
+	})
+
+	t.Run("invalid export option", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl)
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s.clients.LoggingService = loggingClient
+		invalid := proto.Clone(query).(*api.ResponseQuery)
+		invalid.DerivedColumns = []*api.DerivedColumn{{Name: "copy", Expression: "Q9"}}
+		err := s.GetResponsesCSV(invalid, &mockExportStream{ctx: context.Background()})
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_ERROR, "format: csv", "outcome: failed: rpc error: code = InvalidArgument")
+	})
+
+	t.Run("cancelled export", func(t *testing.T) {
+		s, _ := newTestServer(mockCtrl, mockResponse("p1", 1000, "yes"))
+		loggingClient, events := expectLogEvent(t, mockCtrl)
+		s.clients.LoggingService = loggingClient
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := s.GetResponsesCSV(query, &mockExportStream{ctx: ctx})
+		if status.Code(err) != codes.Canceled {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		checkLogEvent(t, *events, loggingAPI.LogEventType_ERROR, "format: csv", "outcome: failed: rpc error: code = Canceled")
+	})
+}
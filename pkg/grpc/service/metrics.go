@@ -0,0 +1,49 @@
+package service
+
+import (
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/metrics"
+)
+
+// exportObserver records the metrics of a single export, done has to be called when it ends
+type exportObserver struct {
+	studyKey  string
+	surveyKey string
+	format    string
+	rows      int
+	bytes     int
+}
+
+func newExportObserver(studyKey string, surveyKey string) *exportObserver {
+	metrics.ExportsInFlight.Inc()
+	return &exportObserver{
+		studyKey:  studyKey,
+		surveyKey: surveyKey,
+		format:    "unknown",
+	}
+}
+
+// stream counts the bytes sent through it
+func (o *exportObserver) stream(stream chunkStream) chunkStream {
+	return &countingStream{chunkStream: stream, observer: o}
+}
+
+func (o *exportObserver) done(err error) {
+	metrics.ExportsInFlight.Dec()
+	metrics.Exports.WithLabelValues(o.studyKey, o.surveyKey, o.format, metrics.Outcome(err)).Inc()
+	if err != nil {
+		return
+	}
+	metrics.ExportRows.WithLabelValues(o.format).Observe(float64(o.rows))
+	metrics.ExportBytes.WithLabelValues(o.format).Observe(float64(o.bytes))
+}
+
+type countingStream struct {
+	chunkStream
+	observer *exportObserver
+}
+
+func (s *countingStream) Send(chunk *api.Chunk) error {
+	s.observer.bytes += len(chunk.Chunk) + len(chunk.QualityReport)
+	return s.chunkStream.Send(chunk)
+}
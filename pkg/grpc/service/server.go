@@ -2,14 +2,27 @@ package service
 
 import (
 	"context"
-	"log"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
+	"github.com/influenzanet/data-service/internal/config"
+	"github.com/influenzanet/data-service/pkg/admission"
 	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/export_profiles"
+	requestlog "github.com/influenzanet/data-service/pkg/logger"
+	"github.com/influenzanet/data-service/pkg/metrics"
+	"github.com/influenzanet/data-service/pkg/response_parser"
 	"github.com/influenzanet/data-service/pkg/types"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	// registers the gzip compressor, so clients can request compressed messages
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
 const (
@@ -18,32 +31,87 @@ const (
 )
 
 type dataServiceServer struct {
-	clients *types.APIClients
+	clients      *types.APIClients
+	policy       export_policy.Policy
+	profiles     export_profiles.Profiles
+	timeouts     config.Timeouts
+	limits       config.ExportLimits
+	admission    *admission.Controller
+	maxQueueWait time.Duration
+	health       *healthChecker
 }
 
 // NewUserManagementServer creates a new service instance
 func NewDataServiceServer(
 	clients *types.APIClients,
+	conf config.Config,
+	logger *zap.Logger,
 ) api.DataServiceApiServer {
+	return newDataServiceServer(clients, conf, logger)
+}
+
+func newDataServiceServer(clients *types.APIClients, conf config.Config, logger *zap.Logger) *dataServiceServer {
+	a := conf.ExportAdmission
 	return &dataServiceServer{
-		clients: clients,
+		clients:  clients,
+		policy:   conf.ExportPolicy,
+		profiles: conf.ExportProfiles,
+		timeouts: conf.Timeouts,
+		limits:   conf.ExportLimits,
+		admission: admission.NewController(admission.Limits{
+			MaxConcurrent: a.MaxConcurrent,
+			MaxPerUser:    a.MaxPerUser,
+			MaxPerStudy:   a.MaxPerStudy,
+			MaxQueue:      a.MaxQueue,
+			MemoryBudget:  a.MemoryBudget,
+		}, func(queued int, memory int64) {
+			metrics.ExportsQueued.Set(float64(queued))
+			metrics.ExportMemoryReserved.Set(float64(memory))
+		}),
+		maxQueueWait: a.MaxQueueWait.Duration,
+		health:       newHealthChecker(clients, conf.HealthCheck, logger),
 	}
 }
 
 // RunServer runs gRPC service
-func RunServer(ctx context.Context, port string,
+func RunServer(ctx context.Context, conf config.Config,
 	clients *types.APIClients,
+	logger *zap.Logger,
 ) error {
+	port := conf.Port
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		logger.Fatal("failed to listen", zap.Error(err))
+	}
+
+	opts, err := serverOptions(conf, logger)
+	if err != nil {
+		return err
+	}
+
+	response_parser.SetParseErrorHook(func(kind string) {
+		metrics.ParseErrors.WithLabelValues(kind).Inc()
+	})
+	var metricsServer *http.Server
+	if conf.MetricsPort != "" {
+		metricsServer = metrics.NewServer(conf.MetricsPort)
+		go func() {
+			logger.Info("serving metrics", zap.String("port", conf.MetricsPort))
+			if err := metricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Fatal("failed to serve metrics", zap.Error(err))
+			}
+		}()
 	}
 
 	// register service
-	server := grpc.NewServer()
-	api.RegisterDataServiceApiServer(server, NewDataServiceServer(
-		clients,
-	))
+	server := grpc.NewServer(opts...)
+	srv := newDataServiceServer(clients, conf, logger)
+	api.RegisterDataServiceApiServer(server, srv)
+	healthpb.RegisterHealthServer(server, srv.health.server)
+
+	healthCtx, stopHealthChecks := context.WithCancel(ctx)
+	defer stopHealthChecks()
+	go srv.health.run(healthCtx)
 
 	// graceful shutdown
 	c := make(chan os.Signal, 1)
@@ -51,14 +119,39 @@ func RunServer(ctx context.Context, port string,
 	go func() {
 		for range c {
 			// sig is a ^C, handle it
-			log.Println("shutting down gRPC server...")
+			logger.Info("shutting down gRPC server...")
+			stopHealthChecks()
+			srv.health.shutdown()
+			if metricsServer != nil {
+				metricsServer.Close()
+			}
 			server.GracefulStop()
 			<-ctx.Done()
 		}
 	}()
 
 	// start gRPC server
-	log.Println("starting gRPC server...")
-	log.Println("wait connections on port " + port)
+	logger.Info("starting gRPC server...", zap.String("port", port))
 	return server.Serve(lis)
 }
+
+func serverOptions(conf config.Config, logger *zap.Logger) ([]grpc.ServerOption, error) {
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(requestlog.UnaryServerInterceptor(logger)),
+		grpc.ChainStreamInterceptor(requestlog.StreamServerInterceptor(logger)),
+	}
+	if conf.MessageSizes.MaxRecv > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(conf.MessageSizes.MaxRecv))
+	}
+	if conf.MessageSizes.MaxSend > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(conf.MessageSizes.MaxSend))
+	}
+	if conf.TLS.CertFile != "" {
+		tlsConf, err := config.ServerTLSConfig(conf.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConf)))
+	}
+	return opts, nil
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/influenzanet/data-service/pkg/admission"
+	"github.com/influenzanet/data-service/pkg/api"
+	"github.com/influenzanet/data-service/pkg/export_policy"
+	"github.com/influenzanet/data-service/pkg/export_profiles"
+	"github.com/influenzanet/data-service/pkg/logger"
+	"github.com/influenzanet/data-service/pkg/response_parser"
+	"github.com/influenzanet/go-utils/pkg/api_types"
+	"github.com/influenzanet/go-utils/pkg/constants"
+	"github.com/influenzanet/go-utils/pkg/token_checks"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *dataServiceServer) GetResponseStatistics(ctx context.Context, req *api.ResponseStatisticsQuery) (*api.ResponseStatistics, error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" || req.SurveyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if err := checkQuantiles(req.Quantiles); err != nil {
+		return nil, err
+	}
+
+	var stats []response_parser.QuestionStatistics
+	err := s.aggregateStudyResponses(ctx, responseSelection{
+		Token:             req.Token,
+		StudyKey:          req.StudyKey,
+		SurveyKey:         req.SurveyKey,
+		From:              req.From,
+		Until:             req.Until,
+		ShortQuestionKeys: req.ShortQuestionKeys,
+		Separator:         req.Separator,
+		ParticipantIDs:    req.ParticipantIds,
+		Format:            "statistics",
+	}, func(rp *response_parser.ResponseParser) error {
+		stats = rp.GetResponseStatistics(req.Quantiles)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &api.ResponseStatistics{
+		SurveyKey: req.SurveyKey,
+		Questions: make([]*api.QuestionStatistics, len(stats)),
+	}
+	for i, q := range stats {
+		resp.Questions[i] = q.ToAPI()
+	}
+	return resp, nil
+}
+
+func (s *dataServiceServer) GetResponseTimeSeries(ctx context.Context, req *api.ResponseTimeSeriesQuery) (*api.ResponseTimeSeries, error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" || req.SurveyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if err := checkQuantiles(req.Quantiles); err != nil {
+		return nil, err
+	}
+
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = response_parser.TIME_BUCKET_WEEK
+	}
+	if bucket != response_parser.TIME_BUCKET_DAY &&
+		bucket != response_parser.TIME_BUCKET_WEEK &&
+		bucket != response_parser.TIME_BUCKET_MONTH {
+		return nil, status.Error(codes.InvalidArgument, "unknown time bucket")
+	}
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "unknown timezone")
+	}
+
+	var buckets []response_parser.TimeSeriesBucket
+	err = s.aggregateStudyResponses(ctx, responseSelection{
+		Token:             req.Token,
+		StudyKey:          req.StudyKey,
+		SurveyKey:         req.SurveyKey,
+		From:              req.From,
+		Until:             req.Until,
+		ShortQuestionKeys: req.ShortQuestionKeys,
+		Separator:         req.Separator,
+		ParticipantIDs:    req.ParticipantIds,
+		Format:            "time-series",
+	}, func(rp *response_parser.ResponseParser) (err error) {
+		buckets, err = rp.GetResponseTimeSeries(bucket, loc, req.QuestionKeys, req.Quantiles)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.ResponseTimeSeries{
+		SurveyKey: req.SurveyKey,
+		Bucket:    bucket,
+		Timezone:  timezone,
+		Buckets:   make([]*api.TimeSeriesBucket, len(buckets)),
+	}
+	for i, b := range buckets {
+		resp.Buckets[i] = b.ToAPI()
+	}
+	return resp, nil
+}
+
+func (s *dataServiceServer) GetWeeklyIncidence(ctx context.Context, req *api.IncidenceQuery) (*api.Incidence, error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" || req.SurveyKey == "" || len(req.CaseDefinitions) < 1 {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "unknown timezone")
+	}
+
+	var weeks []response_parser.IncidenceBucket
+	err = s.aggregateStudyResponses(ctx, responseSelection{
+		Token:             req.Token,
+		StudyKey:          req.StudyKey,
+		SurveyKey:         req.SurveyKey,
+		From:              req.From,
+		Until:             req.Until,
+		ShortQuestionKeys: req.ShortQuestionKeys,
+		Separator:         req.Separator,
+		CaseDefinitions:   caseDefinitionsFromAPI(req.CaseDefinitions),
+		Format:            "incidence",
+	}, func(rp *response_parser.ResponseParser) (err error) {
+		weeks, err = rp.GetWeeklyIncidence(loc, response_parser.ActiveParticipantRulesFromAPI(req.Rules))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.Incidence{
+		SurveyKey: req.SurveyKey,
+		Timezone:  timezone,
+		Weeks:     make([]*api.IncidenceBucket, len(weeks)),
+	}
+	for i, w := range weeks {
+		resp.Weeks[i] = w.ToAPI()
+	}
+	return resp, nil
+}
+
+func (s *dataServiceServer) GetActiveParticipants(ctx context.Context, req *api.ActiveParticipantsQuery) (*api.ActiveParticipants, error) {
+	if req == nil || token_checks.IsTokenEmpty(req.Token) || req.StudyKey == "" || req.SurveyKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing argument")
+	}
+	if req.Rules != nil && req.Rules.MinReports < 0 {
+		return nil, status.Error(codes.InvalidArgument, "min reports must not be negative")
+	}
+
+	timezone := req.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "unknown timezone")
+	}
+
+	var weeks []response_parser.ActiveParticipantsBucket
+	err = s.aggregateStudyResponses(ctx, responseSelection{
+		Token:     req.Token,
+		StudyKey:  req.StudyKey,
+		SurveyKey: req.SurveyKey,
+		From:      req.From,
+		Until:     req.Until,
+		Format:    "active-participants",
+	}, func(rp *response_parser.ResponseParser) (err error) {
+		weeks, err = rp.GetActiveParticipants(loc, response_parser.ActiveParticipantRulesFromAPI(req.Rules))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := &api.ActiveParticipants{
+		SurveyKey: req.SurveyKey,
+		Timezone:  timezone,
+		Weeks:     make([]*api.ActiveParticipantsBucket, len(weeks)),
+	}
+	for i, w := range weeks {
+		resp.Weeks[i] = w.ToAPI()
+	}
+	return resp, nil
+}
+
+type responseSelection struct {
+	Token             *api_types.TokenInfos
+	StudyKey          string
+	SurveyKey         string
+	From              int64
+	Until             int64
+	ShortQuestionKeys bool
+	Separator         string
+	ParticipantIDs    []string
+	CaseDefinitions   []response_parser.CaseDefinition
+	DuplicateWindow   time.Duration
+	TimestampFormat   response_parser.TimestampFormat
+	// written to the audit log
+	Format string
+	// name of the export profile, written to the audit log
+	ProfileName string
+	// the column filter and restrictions of the profile are applied if set
+	Profile *export_profiles.Profile
+	// 0 means no limit
+	MaxResponses int
+	// memory of the responses is reserved if set
+	Ticket *admission.Ticket
+}
+
+func (sel responseSelection) dataAccess() dataAccess {
+	return dataAccess{
+		Token:     sel.Token,
+		EventName: constants.LOG_EVENT_DOWNLOAD_RESPONSES,
+		StudyKey:  sel.StudyKey,
+		SurveyKey: sel.SurveyKey,
+		From:      sel.From,
+		Until:     sel.Until,
+		Format:    sel.Format,
+		Profile:   sel.ProfileName,
+	}
+}
+
+// aggregateStudyResponses parses the responses and passes them to aggregate. The access is written to the
+// audit log with the outcome of the aggregation. Like exports, the request is bounded by the maximum export
+// duration, time range and number of responses, and it is admitted by the admission controller.
+func (s *dataServiceServer) aggregateStudyResponses(ctx context.Context, sel responseSelection, aggregate func(rp *response_parser.ResponseParser) error) error {
+	ctx, cancel := s.exportContext(ctx)
+	defer cancel()
+
+	if err := s.checkTimeRange(sel.From, sel.Until); err != nil {
+		return err
+	}
+	restrictions, err := s.checkExportPolicy(ctx, sel.dataAccess())
+	if err != nil {
+		return err
+	}
+	// the caller only knows the pseudonyms, matching real IDs would reveal who takes part in the study
+	if restrictions.Pseudonymised && len(sel.ParticipantIDs) > 0 {
+		err := status.Error(codes.PermissionDenied, "participant IDs cannot be selected from pseudonymised data")
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, true)
+		return err
+	}
+	ticket, err := s.admitExport(ctx, sel.Token, sel.StudyKey, nil)
+	if err != nil {
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return err
+	}
+	defer ticket.Release()
+	sel.Ticket = ticket
+	sel.MaxResponses = s.limits.MaxResponses
+
+	rp, err := s.parseStudyResponses(ctx, sel, restrictions)
+	if err != nil {
+		return err
+	}
+	if err := aggregate(rp); err != nil {
+		if ctxErr := s.contextError(ctx); ctxErr != nil {
+			err = ctxErr
+		} else {
+			err = status.Error(codes.Internal, err.Error())
+		}
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return err
+	}
+	access := sel.dataAccess()
+	access.RowCount = len(rp.GetResponses())
+	s.saveDataAccessLog(ctx, access, nil, false)
+	return nil
+}
+
+// parseStudyResponses fetches the survey definition and all matching responses into a new response parser.
+// The export policy has to be checked before, restrictions are the result of checkExportPolicy.
+func (s *dataServiceServer) parseStudyResponses(ctx context.Context, sel responseSelection, restrictions export_policy.Restrictions) (*response_parser.ResponseParser, error) {
+	surveyDef, err := s.getSurveyDef(ctx, sel.Token, sel.StudyKey, sel.SurveyKey)
+	if err != nil {
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, true)
+		return nil, err
+	}
+
+	rp, err := response_parser.NewResponseParser(surveyDef, "ignored", sel.ShortQuestionKeys, sel.Separator, logger.FromContext(ctx))
+	if err != nil {
+		err = status.Error(codes.Internal, err.Error())
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return nil, err
+	}
+	if sel.Profile != nil {
+		if err := s.applyProfile(rp, *sel.Profile, restrictions); err != nil {
+			err = status.Error(codes.InvalidArgument, err.Error())
+			s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+			return nil, err
+		}
+	} else {
+		s.applyRestrictions(rp, restrictions)
+	}
+	if err := rp.SetCaseDefinitions(sel.CaseDefinitions); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return nil, err
+	}
+	rp.SetDuplicateWindow(sel.DuplicateWindow)
+	if err := rp.SetTimestampFormat(sel.TimestampFormat); err != nil {
+		err = status.Error(codes.InvalidArgument, err.Error())
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+		return nil, err
+	}
+
+	participantFilter := map[string]bool{}
+	for _, pID := range sel.ParticipantIDs {
+		participantFilter[pID] = true
+	}
+
+	respStream, err := s.clients.StudyService.StreamStudyResponses(ctx, &studyAPI.SurveyResponseQuery{
+		Token:     sel.Token,
+		StudyKey:  sel.StudyKey,
+		SurveyKey: sel.SurveyKey,
+		From:      sel.From,
+		Until:     sel.Until,
+	})
+	if err != nil {
+		s.saveDataAccessLog(ctx, sel.dataAccess(), err, true)
+		return nil, err
+	}
+	count := 0
+	for {
+		if err := s.contextError(ctx); err != nil {
+			s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+			return nil, err
+		}
+		r, err := respStream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if ctxErr := s.contextError(ctx); ctxErr != nil {
+				s.saveDataAccessLog(ctx, sel.dataAccess(), ctxErr, false)
+				return nil, ctxErr
+			}
+			logger.FromContext(ctx).Error("failed to receive responses", zap.Error(err))
+			s.saveDataAccessLog(ctx, sel.dataAccess(), err, true)
+			return nil, err
+		}
+		if len(participantFilter) > 0 && !participantFilter[r.ParticipantId] {
+			continue
+		}
+		count++
+		if err := checkResponseCount(count, sel.MaxResponses); err != nil {
+			s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+			return nil, err
+		}
+		if sel.Ticket != nil {
+			if err := reserveResponse(sel.Ticket, r); err != nil {
+				s.saveDataAccessLog(ctx, sel.dataAccess(), err, false)
+				return nil, err
+			}
+		}
+		err = rp.AddResponse(r)
+		if err != nil {
+			logger.FromContext(ctx).Warn("failed to parse response", zap.Error(err))
+		}
+	}
+	return rp, nil
+}
+
+func checkQuantiles(quantiles []float64) error {
+	for _, q := range quantiles {
+		if q < 0 || q > 1 {
+			return status.Error(codes.InvalidArgument, "quantiles must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+func caseDefinitionsFromAPI(caseDefs []*api.CaseDefinition) []response_parser.CaseDefinition {
+	res := make([]response_parser.CaseDefinition, len(caseDefs))
+	for i, cd := range caseDefs {
+		res[i] = response_parser.CaseDefinitionFromAPI(cd)
+	}
+	return res
+}
@@ -9,9 +9,10 @@ import (
 
 // Status endpoint should return internal status of the system if running correctly
 func (s *dataServiceServer) Status(ctx context.Context, _ *empty.Empty) (*api_types.ServiceStatus, error) {
+	status, msg := s.health.status()
 	return &api_types.ServiceStatus{
-		Status:  api_types.ServiceStatus_NORMAL,
-		Msg:     "service running",
+		Status:  status,
+		Msg:     msg,
 		Version: apiVersion,
 	}, nil
 }
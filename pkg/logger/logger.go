@@ -0,0 +1,160 @@
+// Package logger creates the structured logger of the service and attaches a request ID to the logger of
+// each request. The request ID is read from and propagated through the gRPC metadata.
+package logger
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// RequestIDKey is the gRPC metadata key of the request ID
+	RequestIDKey = "x-request-id"
+
+	maxRequestIDLength = 64
+)
+
+// New creates a logger, level is one of debug, info, warn or error (default info) and format is json
+// (default) or console
+func New(level string, format string) (*zap.Logger, error) {
+	var conf zap.Config
+	switch format {
+	case "", "json":
+		conf = zap.NewProductionConfig()
+	case "console":
+		conf = zap.NewDevelopmentConfig()
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", format)
+	}
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	conf.Level = zap.NewAtomicLevelAt(lvl)
+	conf.EncoderConfig.TimeKey = "time"
+	conf.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	return conf.Build()
+}
+
+// ParseLevel reads the log level, empty means info
+func ParseLevel(level string) (zapcore.Level, error) {
+	var lvl zapcore.Level
+	if level == "" {
+		return zapcore.InfoLevel, nil
+	}
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return lvl, fmt.Errorf("unknown log level: %s", level)
+	}
+	return lvl, nil
+}
+
+type loggerKey struct{}
+type requestIDKey struct{}
+
+// FromContext returns the logger of the request or the global logger
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return l
+	}
+	return zap.L()
+}
+
+// RequestID returns the ID of the request, empty if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// withRequest takes the request ID from the incoming metadata or generates one, and adds it and a logger
+// with the request ID and method to the context
+func withRequest(ctx context.Context, base *zap.Logger, method string) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(RequestIDKey); len(ids) > 0 && validRequestID(ids[0]) {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	ctx = context.WithValue(ctx, loggerKey{}, base.With(zap.String("requestID", id), zap.String("method", method)))
+	return ctx, id
+}
+
+// validRequestID accepts printable ASCII only, so IDs from clients cannot break log lines
+func validRequestID(id string) bool {
+	if len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// UnaryServerInterceptor adds the request logger to the context and returns the request ID in the header
+func UnaryServerInterceptor(base *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := withRequest(ctx, base, info.FullMethod)
+		if err := grpc.SetHeader(ctx, metadata.Pairs(RequestIDKey, id)); err != nil {
+			FromContext(ctx).Debug("failed to set request ID header", zap.Error(err))
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor adds the request logger to the stream context and returns the request ID in the
+// header
+func StreamServerInterceptor(base *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := withRequest(ss.Context(), base, info.FullMethod)
+		if err := ss.SetHeader(metadata.Pairs(RequestIDKey, id)); err != nil {
+			FromContext(ctx).Debug("failed to set request ID header", zap.Error(err))
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryClientInterceptor passes the request ID on to other services
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+}
+
+// StreamClientInterceptor passes the request ID on to other services
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	if id := RequestID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+	}
+	return ctx
+}
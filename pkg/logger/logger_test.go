@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestWithRequest(t *testing.T) {
+	t.Run("request ID from metadata", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "abc-123"))
+		ctx, id := withRequest(ctx, zap.NewNop(), "/test")
+		if id != "abc-123" || RequestID(ctx) != "abc-123" {
+			t.Errorf("unexpected request ID: %s", id)
+		}
+		if FromContext(ctx) == zap.L() {
+			t.Error("request logger expected")
+		}
+	})
+
+	t.Run("generated request ID", func(t *testing.T) {
+		for _, incoming := range []string{"", "with space", "line\nbreak"} {
+			ctx := context.Background()
+			if incoming != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(RequestIDKey, incoming))
+			}
+			_, id := withRequest(ctx, zap.NewNop(), "/test")
+			if id == incoming || len(id) != 16 {
+				t.Errorf("unexpected request ID for %q: %s", incoming, id)
+			}
+		}
+	})
+
+	t.Run("propagated to other services", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "abc-123"))
+		ctx, _ = withRequest(ctx, zap.NewNop(), "/test")
+		md, _ := metadata.FromOutgoingContext(outgoingContext(ctx))
+		if ids := md.Get(RequestIDKey); len(ids) != 1 || ids[0] != "abc-123" {
+			t.Errorf("unexpected outgoing metadata: %v", md)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("verbose", ""); err == nil {
+		t.Error("unknown level should fail with error")
+	}
+	if _, err := New("", "xml"); err == nil {
+		t.Error("unknown format should fail with error")
+	}
+	if _, err := New("debug", "console"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Package metrics defines the Prometheus metrics of the data service and the HTTP endpoint to scrape them
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "data_service"
+
+var (
+	// Exports counts finished exports
+	Exports = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "exports_total",
+		Help:      "Number of exports by study, survey, format and outcome.",
+	}, []string{"study", "survey", "format", "outcome"})
+
+	// ExportRows is the number of responses of successful exports
+	ExportRows = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "export_rows",
+		Help:      "Number of rows per successful export.",
+		Buckets:   prometheus.ExponentialBuckets(10, 4, 10),
+	}, []string{"format"})
+
+	// ExportBytes is the number of bytes sent per export, after compression
+	ExportBytes = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "export_bytes",
+		Help:      "Number of bytes sent per successful export.",
+		Buckets:   prometheus.ExponentialBuckets(1024, 4, 11),
+	}, []string{"format"})
+
+	// ExportsInFlight is the number of running exports
+	ExportsInFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "exports_in_flight",
+		Help:      "Number of exports currently running.",
+	})
+
+	// ExportsQueued is the number of exports waiting for a free slot
+	ExportsQueued = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "exports_queued",
+		Help:      "Number of exports waiting in the queue.",
+	})
+
+	// ExportMemoryReserved is the estimated memory of the responses of all running exports
+	ExportMemoryReserved = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "export_memory_reserved_bytes",
+		Help:      "Estimated memory reserved by running exports.",
+	})
+
+	// ParseErrors counts responses that could not be parsed completely
+	ParseErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "parse_errors_total",
+		Help:      "Number of response parsing errors by kind.",
+	}, []string{"kind"})
+
+	// UpstreamCallDuration is the latency of calls to the study and logging service. For streams it is the
+	// time until the stream ended.
+	UpstreamCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "upstream_call_duration_seconds",
+		Help:      "Latency of calls to other services by service, method and status code.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 4, 10),
+	}, []string{"service", "method", "code"})
+)
+
+// Outcome is the label for the result of a request
+func Outcome(err error) string {
+	if err == nil {
+		return "success"
+	}
+	return status.Code(err).String()
+}
+
+// NewServer returns the HTTP server for the /metrics endpoint
+func NewServer(port string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	return &http.Server{
+		Addr:    ":" + port,
+		Handler: mux,
+	}
+}
+
+// UnaryClientInterceptor records the latency of unary calls to other services
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	observeUpstreamCall(method, start, err)
+	return err
+}
+
+// StreamClientInterceptor records the latency of streams from other services until they end
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	start := time.Now()
+	cs, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		observeUpstreamCall(method, start, err)
+		return nil, err
+	}
+	return &observedClientStream{ClientStream: cs, method: method, start: start}, nil
+}
+
+type observedClientStream struct {
+	grpc.ClientStream
+	method   string
+	start    time.Time
+	observed bool
+}
+
+func (s *observedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil && !s.observed {
+		s.observed = true
+		if err == io.EOF {
+			observeUpstreamCall(s.method, s.start, nil)
+		} else {
+			observeUpstreamCall(s.method, s.start, err)
+		}
+	}
+	return err
+}
+
+// observeUpstreamCall splits full method names like "/package.Service/Method" into the labels
+func observeUpstreamCall(fullMethod string, start time.Time, err error) {
+	service, method := "unknown", fullMethod
+	if parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/"); len(parts) == 2 {
+		service, method = parts[0], parts[1]
+	}
+	UpstreamCallDuration.WithLabelValues(service, method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,77 @@
+package response_parser
+
+import (
+	"sort"
+	"time"
+)
+
+// selectCountedResponses drops the responses that should not be counted for active participants.
+// The rules are applied to the responses of the parser, i.e. "first report" and the number of reports
+// refer to the queried time range, which is usually one season.
+func selectCountedResponses(responses []ParsedResponse, rules ActiveParticipantRules) []ParsedResponse {
+	reportCounts := map[string]int{}
+	firstReport := map[string]int{}
+	for i, resp := range responses {
+		reportCounts[resp.ParticipantID] += 1
+		first, ok := firstReport[resp.ParticipantID]
+		if !ok || resp.SubmittedAt < responses[first].SubmittedAt {
+			firstReport[resp.ParticipantID] = i
+		}
+	}
+
+	counted := []ParsedResponse{}
+	for i, resp := range responses {
+		if reportCounts[resp.ParticipantID] < rules.MinReports {
+			continue
+		}
+		if rules.ExcludeFirstReport && firstReport[resp.ParticipantID] == i {
+			continue
+		}
+		counted = append(counted, resp)
+	}
+	return counted
+}
+
+// GetActiveParticipants counts for each ISO week the participants with at least one counted report
+func (rp ResponseParser) GetActiveParticipants(loc *time.Location, rules ActiveParticipantRules) ([]ActiveParticipantsBucket, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	type weekCounter struct {
+		bucket ActiveParticipantsBucket
+		active map[string]bool
+	}
+
+	weeks := map[string]*weekCounter{}
+	for _, resp := range selectCountedResponses(rp.responses, rules) {
+		label, start, end, err := getTimeBucket(resp.SubmittedAt, TIME_BUCKET_WEEK, loc)
+		if err != nil {
+			return nil, err
+		}
+		w, ok := weeks[label]
+		if !ok {
+			w = &weekCounter{
+				bucket: ActiveParticipantsBucket{
+					Label: label,
+					Start: start,
+					End:   end,
+				},
+				active: map[string]bool{},
+			}
+			weeks[label] = w
+		}
+		w.active[resp.ParticipantID] = true
+		w.bucket.Reports += 1
+	}
+
+	buckets := []ActiveParticipantsBucket{}
+	for _, w := range weeks {
+		w.bucket.ActiveParticipants = int64(len(w.active))
+		buckets = append(buckets, w.bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start < buckets[j].Start
+	})
+	return buckets, nil
+}
@@ -0,0 +1,133 @@
+package response_parser
+
+import (
+	"testing"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestSelectCountedResponses(t *testing.T) {
+	responses := []ParsedResponse{
+		{ParticipantID: "p1", SubmittedAt: 20},
+		{ParticipantID: "p1", SubmittedAt: 10},
+		{ParticipantID: "p1", SubmittedAt: 30},
+		{ParticipantID: "p2", SubmittedAt: 15},
+		{ParticipantID: "p3", SubmittedAt: 5},
+		{ParticipantID: "p3", SubmittedAt: 25},
+	}
+
+	t.Run("without rules", func(t *testing.T) {
+		counted := selectCountedResponses(responses, ActiveParticipantRules{})
+		if len(counted) != len(responses) {
+			t.Errorf("unexpected number of responses: %d", len(counted))
+		}
+	})
+
+	t.Run("with min reports", func(t *testing.T) {
+		counted := selectCountedResponses(responses, ActiveParticipantRules{MinReports: 2})
+		if len(counted) != 5 {
+			t.Errorf("unexpected number of responses: %d", len(counted))
+			return
+		}
+		for _, r := range counted {
+			if r.ParticipantID == "p2" {
+				t.Errorf("unexpected response: %v", r)
+			}
+		}
+	})
+
+	t.Run("with exclude first report", func(t *testing.T) {
+		counted := selectCountedResponses(responses, ActiveParticipantRules{ExcludeFirstReport: true})
+		if len(counted) != 3 {
+			t.Errorf("unexpected number of responses: %d", len(counted))
+			return
+		}
+		if counted[0].SubmittedAt != 20 || counted[1].SubmittedAt != 30 || counted[2].SubmittedAt != 25 {
+			t.Errorf("unexpected responses: %v", counted)
+		}
+	})
+
+	t.Run("with both rules", func(t *testing.T) {
+		counted := selectCountedResponses(responses, ActiveParticipantRules{MinReports: 3, ExcludeFirstReport: true})
+		if len(counted) != 2 {
+			t.Errorf("unexpected number of responses: %d", len(counted))
+		}
+	})
+}
+
+func TestGetActiveParticipants(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	week1 := time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC).Unix()
+	week2 := time.Date(2021, 2, 8, 10, 0, 0, 0, time.UTC).Unix()
+	for _, r := range []*studyAPI.SurveyResponse{
+		mockWeeklyILIResponse("p1", week1, []string{"fever", "cough"}, "yes"),
+		mockWeeklyILIResponse("p2", week1, []string{}, "no"),
+		mockWeeklyILIResponse("p1", week2, []string{"fever", "cough"}, "yes"),
+		mockWeeklyILIResponse("p1", week2+60, []string{}, "no"),
+		mockWeeklyILIResponse("p2", week2, []string{}, "no"),
+		mockWeeklyILIResponse("p3", week2, []string{}, "no"),
+	} {
+		if err := parser.AddResponse(r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	t.Run("without rules", func(t *testing.T) {
+		weeks, err := parser.GetActiveParticipants(time.UTC, ActiveParticipantRules{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(weeks) != 2 {
+			t.Errorf("unexpected number of weeks: %d", len(weeks))
+			return
+		}
+		if weeks[0].ActiveParticipants != 2 || weeks[0].Reports != 2 {
+			t.Errorf("unexpected first week: %v", weeks[0])
+		}
+		if weeks[1].ActiveParticipants != 3 || weeks[1].Reports != 4 {
+			t.Errorf("unexpected second week: %v", weeks[1])
+		}
+	})
+
+	t.Run("with exclude first report and min reports", func(t *testing.T) {
+		weeks, err := parser.GetActiveParticipants(time.UTC, ActiveParticipantRules{MinReports: 2, ExcludeFirstReport: true})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(weeks) != 1 {
+			t.Errorf("unexpected number of weeks: %d", len(weeks))
+			return
+		}
+		if weeks[0].Label != "2021-W06" || weeks[0].ActiveParticipants != 2 || weeks[0].Reports != 3 {
+			t.Errorf("unexpected week: %v", weeks[0])
+		}
+	})
+
+	t.Run("incidence uses the same rules", func(t *testing.T) {
+		weeks, err := parser.GetWeeklyIncidence(time.UTC, ActiveParticipantRules{MinReports: 2, ExcludeFirstReport: true})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(weeks) != 1 {
+			t.Errorf("unexpected number of weeks: %d", len(weeks))
+			return
+		}
+		if weeks[0].ActiveParticipants != 2 || weeks[0].Cases[0].Count != 1 || weeks[0].Cases[0].Incidence != 500 {
+			t.Errorf("unexpected week: %v", weeks[0])
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package response_parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SetCaseDefinitions validates the case definitions against the columns of the survey versions.
+// Responses added afterwards get an additional boolean column for each definition.
+func (rp *ResponseParser) SetCaseDefinitions(caseDefs []CaseDefinition) error {
+	knownColumns := rp.getPossibleResponseColumns()
+	names := map[string]bool{}
+	for _, dc := range rp.derivedColumns {
+		names[dc.name] = true
+	}
+	for _, cd := range caseDefs {
+		if cd.Name == "" {
+			return errors.New("case definition name missing")
+		}
+		if names[cd.Name] {
+			return fmt.Errorf("duplicate case definition: %s", cd.Name)
+		}
+		names[cd.Name] = true
+		if err := validateCaseRule(cd.Rule, knownColumns); err != nil {
+			return fmt.Errorf("case definition %s: %v", cd.Name, err)
+		}
+		if err := rp.checkExported(caseRuleColumns(cd.Rule)); err != nil {
+			return fmt.Errorf("case definition %s: %v", cd.Name, err)
+		}
+	}
+	rp.caseDefinitions = caseDefs
+	return nil
+}
+
+// getPossibleResponseColumns collects the response column names any of the survey versions can produce
+func (rp ResponseParser) getPossibleResponseColumns() map[string]bool {
+	columns := map[string]bool{}
+	for _, sv := range rp.surveyVersions {
+		for _, question := range sv.Questions {
+			for k := range getResponseColumns(question, nil, rp.questionOptionKeySep, rp.logger) {
+				columns[k] = true
+			}
+		}
+	}
+	return columns
+}
+
+// caseRuleColumns lists the columns used by the rule and its sub-rules
+func caseRuleColumns(rule CaseRule) []string {
+	cols := []string{}
+	if rule.Column != "" {
+		cols = append(cols, rule.Column)
+	}
+	for _, r := range rule.Rules {
+		cols = append(cols, caseRuleColumns(r)...)
+	}
+	return cols
+}
+
+func validateCaseRule(rule CaseRule, knownColumns map[string]bool) error {
+	switch rule.Operator {
+	case CASE_RULE_AND, CASE_RULE_OR:
+		if len(rule.Rules) < 1 {
+			return fmt.Errorf("%s needs at least one sub-rule", rule.Operator)
+		}
+	case CASE_RULE_NOT:
+		if len(rule.Rules) != 1 {
+			return errors.New("not needs exactly one sub-rule")
+		}
+	case CASE_RULE_HAS_VALUE, CASE_RULE_IS_TRUE:
+		if !knownColumns[rule.Column] {
+			return fmt.Errorf("unknown column: %s", rule.Column)
+		}
+		return nil
+	case CASE_RULE_EQUALS:
+		if !knownColumns[rule.Column] {
+			return fmt.Errorf("unknown column: %s", rule.Column)
+		}
+		if len(rule.Values) < 1 {
+			return errors.New("equals needs at least one value")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown operator: %s", rule.Operator)
+	}
+
+	for _, r := range rule.Rules {
+		if err := validateCaseRule(r, knownColumns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func evalCaseRule(rule CaseRule, responseCols map[string]string) bool {
+	switch rule.Operator {
+	case CASE_RULE_AND:
+		for _, r := range rule.Rules {
+			if !evalCaseRule(r, responseCols) {
+				return false
+			}
+		}
+		return true
+	case CASE_RULE_OR:
+		for _, r := range rule.Rules {
+			if evalCaseRule(r, responseCols) {
+				return true
+			}
+		}
+		return false
+	case CASE_RULE_NOT:
+		return len(rule.Rules) == 1 && !evalCaseRule(rule.Rules[0], responseCols)
+	case CASE_RULE_HAS_VALUE:
+		return responseCols[rule.Column] != ""
+	case CASE_RULE_EQUALS:
+		v, ok := responseCols[rule.Column]
+		return ok && containsKey(rule.Values, v)
+	case CASE_RULE_IS_TRUE:
+		return responseCols[rule.Column] == TRUE_VALUE
+	default:
+		return false
+	}
+}
+
+// GetWeeklyIncidence counts for each ISO week the active participants and the participants with at least
+// one response matching each case definition. The rules select the responses counted for both.
+func (rp ResponseParser) GetWeeklyIncidence(loc *time.Location, rules ActiveParticipantRules) ([]IncidenceBucket, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	type weekCounter struct {
+		label  string
+		start  int64
+		end    int64
+		active map[string]bool
+		cases  map[string]map[string]bool
+	}
+
+	weeks := map[string]*weekCounter{}
+	for _, resp := range selectCountedResponses(rp.responses, rules) {
+		label, start, end, err := getTimeBucket(resp.SubmittedAt, TIME_BUCKET_WEEK, loc)
+		if err != nil {
+			return nil, err
+		}
+		w, ok := weeks[label]
+		if !ok {
+			w = &weekCounter{
+				label:  label,
+				start:  start,
+				end:    end,
+				active: map[string]bool{},
+				cases:  map[string]map[string]bool{},
+			}
+			for _, cd := range rp.caseDefinitions {
+				w.cases[cd.Name] = map[string]bool{}
+			}
+			weeks[label] = w
+		}
+		w.active[resp.ParticipantID] = true
+		for _, cd := range rp.caseDefinitions {
+			if resp.Derived[cd.Name] == TRUE_VALUE {
+				w.cases[cd.Name][resp.ParticipantID] = true
+			}
+		}
+	}
+
+	buckets := []IncidenceBucket{}
+	for _, w := range weeks {
+		b := IncidenceBucket{
+			Label:              w.label,
+			Start:              w.start,
+			End:                w.end,
+			ActiveParticipants: int64(len(w.active)),
+			Cases:              []CaseCount{},
+		}
+		for _, cd := range rp.caseDefinitions {
+			b.Cases = append(b.Cases, newCaseCount(cd.Name, int64(len(w.cases[cd.Name])), b.ActiveParticipants))
+		}
+		buckets = append(buckets, b)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start < buckets[j].Start
+	})
+	return buckets, nil
+}
+
+func newCaseCount(name string, cases int64, activeParticipants int64) CaseCount {
+	c := CaseCount{
+		Name:  name,
+		Count: cases,
+	}
+	if activeParticipants > 0 {
+		c.Incidence = float64(cases) / float64(activeParticipants) * 1000
+	}
+	return c
+}
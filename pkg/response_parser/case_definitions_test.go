@@ -0,0 +1,226 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func mockWeeklyILISurvey(testLang string) *studyAPI.Survey {
+	return &studyAPI.Survey{
+		Id: "surveyIDfromDB",
+		Current: &studyAPI.SurveyVersion{
+			Published: 10,
+			VersionId: "1",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key: "weekly",
+				Items: []*studyAPI.SurveyItem{
+					mockQuestion("weekly.Q1", testLang, "Symptoms", mockMultipleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "fever", Role: "option", Label: "Fever"},
+						{Key: "malaise", Role: "option", Label: "Malaise"},
+						{Key: "headache", Role: "option", Label: "Headache"},
+						{Key: "myalgia", Role: "option", Label: "Muscle pain"},
+						{Key: "cough", Role: "option", Label: "Cough"},
+						{Key: "sorethroat", Role: "option", Label: "Sore throat"},
+						{Key: "breath", Role: "option", Label: "Shortness of breath"},
+					})),
+					mockQuestion("weekly.Q2", testLang, "Sudden onset", mockSingleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "yes", Role: "option", Label: "Yes"},
+						{Key: "no", Role: "option", Label: "No"},
+					})),
+				},
+			},
+		},
+	}
+}
+
+func mockWeeklyILIResponse(pID string, submittedAt int64, symptoms []string, suddenOnset string) *studyAPI.SurveyResponse {
+	symptomItems := []*studyAPI.ResponseItem{}
+	for _, k := range symptoms {
+		symptomItems = append(symptomItems, &studyAPI.ResponseItem{Key: k})
+	}
+	return &studyAPI.SurveyResponse{Key: "weekly", ParticipantId: pID, SubmittedAt: submittedAt, VersionId: "1",
+		Responses: []*studyAPI.SurveyItemResponse{
+			{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "mcg", Items: symptomItems},
+			}}},
+			{Key: "weekly.Q2", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: suddenOnset}}},
+			}}},
+		},
+	}
+}
+
+// ECDC ILI: sudden onset AND (fever OR malaise OR headache OR myalgia) AND (cough OR sore throat OR shortness of breath)
+var testILICaseDefinition = CaseDefinition{
+	Name: "ili",
+	Rule: CaseRule{Operator: CASE_RULE_AND, Rules: []CaseRule{
+		{Operator: CASE_RULE_EQUALS, Column: "Q2", Values: []string{"yes"}},
+		{Operator: CASE_RULE_OR, Rules: []CaseRule{
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-fever"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-malaise"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-headache"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-myalgia"},
+		}},
+		{Operator: CASE_RULE_OR, Rules: []CaseRule{
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-cough"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-sorethroat"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-breath"},
+		}},
+	}},
+}
+
+func TestSetCaseDefinitions(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	t.Run("with missing name", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{{Rule: testILICaseDefinition.Rule}})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with duplicate name", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition, testILICaseDefinition})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with unknown operator", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{{Name: "test", Rule: CaseRule{Operator: "xor"}}})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with unknown column", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{{Name: "test", Rule: CaseRule{Operator: CASE_RULE_AND, Rules: []CaseRule{
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-wrong"},
+		}}}})
+		if err == nil || !strings.Contains(err.Error(), "Q1-wrong") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with valid definition", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestEvalCaseRule(t *testing.T) {
+	responseCols := map[string]string{
+		"Q1-fever":  TRUE_VALUE,
+		"Q1-cough":  FALSE_VALUE,
+		"Q2":        "yes",
+		"Q3":        "",
+		"Q1-breath": "",
+	}
+
+	testCases := []struct {
+		name     string
+		rule     CaseRule
+		expected bool
+	}{
+		{name: "isTrue with true", rule: CaseRule{Operator: CASE_RULE_IS_TRUE, Column: "Q1-fever"}, expected: true},
+		{name: "isTrue with false", rule: CaseRule{Operator: CASE_RULE_IS_TRUE, Column: "Q1-cough"}, expected: false},
+		{name: "equals", rule: CaseRule{Operator: CASE_RULE_EQUALS, Column: "Q2", Values: []string{"no", "yes"}}, expected: true},
+		{name: "equals missing column", rule: CaseRule{Operator: CASE_RULE_EQUALS, Column: "Q4", Values: []string{""}}, expected: false},
+		{name: "hasValue with empty", rule: CaseRule{Operator: CASE_RULE_HAS_VALUE, Column: "Q3"}, expected: false},
+		{name: "not", rule: CaseRule{Operator: CASE_RULE_NOT, Rules: []CaseRule{{Operator: CASE_RULE_HAS_VALUE, Column: "Q3"}}}, expected: true},
+		{name: "and", rule: CaseRule{Operator: CASE_RULE_AND, Rules: []CaseRule{
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-fever"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-cough"},
+		}}, expected: false},
+		{name: "or", rule: CaseRule{Operator: CASE_RULE_OR, Rules: []CaseRule{
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-fever"},
+			{Operator: CASE_RULE_IS_TRUE, Column: "Q1-cough"},
+		}}, expected: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r := evalCaseRule(tc.rule, responseCols); r != tc.expected {
+				t.Errorf("unexpected result: %v", r)
+			}
+		})
+	}
+}
+
+func TestCaseDefinitionColumns(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	week1 := time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC).Unix()
+	week2 := time.Date(2021, 2, 8, 10, 0, 0, 0, time.UTC).Unix()
+	for _, r := range []*studyAPI.SurveyResponse{
+		mockWeeklyILIResponse("p1", week1, []string{"fever", "cough"}, "yes"),
+		mockWeeklyILIResponse("p2", week1, []string{"fever", "cough"}, "no"),
+		mockWeeklyILIResponse("p3", week1, []string{"headache"}, "yes"),
+		mockWeeklyILIResponse("p4", week1, []string{}, "no"),
+		mockWeeklyILIResponse("p1", week2, []string{"myalgia", "breath"}, "yes"),
+		mockWeeklyILIResponse("p1", week2+60, []string{}, "no"),
+		mockWeeklyILIResponse("p2", week2, []string{}, "no"),
+	} {
+		if err := parser.AddResponse(r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	t.Run("derived column values", func(t *testing.T) {
+		responses := parser.GetResponses()
+		expected := []string{TRUE_VALUE, FALSE_VALUE, FALSE_VALUE, FALSE_VALUE, TRUE_VALUE, FALSE_VALUE, FALSE_VALUE}
+		for i, r := range responses {
+			if r.Derived["ili"] != expected[i] {
+				t.Errorf("unexpected value for response %d: %s", i, r.Derived["ili"])
+			}
+		}
+	})
+
+	t.Run("csv contains derived column", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parser.GetResponsesCSV(buf, false); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		header := strings.Split(buf.String(), "\n")[0]
+		if !strings.HasSuffix(header, ",ili") {
+			t.Errorf("unexpected header: %s", header)
+		}
+	})
+
+	t.Run("weekly incidence", func(t *testing.T) {
+		weeks, err := parser.GetWeeklyIncidence(time.UTC, ActiveParticipantRules{})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(weeks) != 2 {
+			t.Errorf("unexpected number of weeks: %d", len(weeks))
+			return
+		}
+		if weeks[0].Label != "2021-W05" || weeks[0].ActiveParticipants != 4 || weeks[0].Cases[0].Count != 1 || weeks[0].Cases[0].Incidence != 250 {
+			t.Errorf("unexpected first week: %v", weeks[0])
+		}
+		if weeks[1].Label != "2021-W06" || weeks[1].ActiveParticipants != 2 || weeks[1].Cases[0].Count != 1 || weeks[1].Cases[0].Incidence != 500 {
+			t.Errorf("unexpected second week: %v", weeks[1])
+		}
+	})
+}
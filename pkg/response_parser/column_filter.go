@@ -0,0 +1,58 @@
+package response_parser
+
+import (
+	"fmt"
+	"path"
+)
+
+// SetColumnFilter limits the exported columns to the ones matching one of the include patterns and none of
+// the exclude patterns. Patterns use the syntax of path.Match, e.g. "Q1-*". Without include patterns all
+// columns are included. The participant ID, version and submission time are always written.
+// The filter has to be set before the case definitions, derived columns and column mappings, they must not
+// copy the values of filtered columns into exported ones.
+func (rp *ResponseParser) SetColumnFilter(include []string, exclude []string) error {
+	for _, p := range append(append([]string{}, include...), exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid column pattern: %s", p)
+		}
+	}
+	rp.includeColumns = include
+	rp.excludeColumns = exclude
+	return nil
+}
+
+func (rp ResponseParser) filterColumns(cols []string) []string {
+	if len(rp.includeColumns) == 0 && len(rp.excludeColumns) == 0 {
+		return cols
+	}
+	filtered := []string{}
+	for _, c := range cols {
+		if rp.isExported(c) {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+func (rp ResponseParser) isExported(col string) bool {
+	return (len(rp.includeColumns) == 0 || matchesColumn(rp.includeColumns, col)) && !matchesColumn(rp.excludeColumns, col)
+}
+
+// checkExported fails for the first column removed by the column filter
+func (rp ResponseParser) checkExported(cols []string) error {
+	for _, c := range cols {
+		if !rp.isExported(c) {
+			return fmt.Errorf("column %s is excluded from the export", c)
+		}
+	}
+	return nil
+}
+
+func matchesColumn(patterns []string, col string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, col); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSetColumnFilter(t *testing.T) {
+	parser := ResponseParser{}
+	if err := parser.SetColumnFilter([]string{"Q1-["}, nil); err == nil {
+		t.Error("should fail with error")
+	}
+}
+
+func TestFilteredExport(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if err := parser.SetColumnFilter([]string{"Q1-*", "ili"}, []string{"Q1-breath", "*metaInit"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if err := parser.AddResponse(mockWeeklyILIResponse("p1", 100, []string{"fever", "cough"}, "yes")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	header := strings.Split(strings.Split(buf.String(), "\n")[0], ",")
+	expected := []string{
+		"participantID", "version", "submitted",
+		"Q1-cough", "Q1-fever", "Q1-headache", "Q1-malaise", "Q1-myalgia", "Q1-sorethroat",
+		"ili",
+		"Q1-metaDisplayed", "Q1-metaItemVersion", "Q1-metaResponse",
+	}
+	if strings.Join(header, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected header: %v", header)
+	}
+}
+
+func TestFilteredColumnsInDefinitions(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetColumnFilter(nil, []string{"Q1-breath"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	t.Run("case definition using an excluded column", func(t *testing.T) {
+		err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition})
+		if err == nil || !strings.Contains(err.Error(), "Q1-breath") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("derived column copying an excluded column", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Name: "copy", Expression: "`Q1-breath`"}})
+		if err == nil || !strings.Contains(err.Error(), "Q1-breath") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("derived column using exported columns", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Name: "fever", Expression: "`Q1-fever` AND participantID"}})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestFilteredColumnsInMappings(t *testing.T) {
+	testCases := []struct {
+		name    string
+		exclude []string
+		mapping ColumnMapping
+		valid   bool
+	}{
+		{name: "question split of an excluded column", exclude: []string{"Q2-z"},
+			mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q2", NewKeys: []string{"Q2a", "Q2b"}}},
+		{name: "option rename of an excluded question", exclude: []string{"Q1"},
+			mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"c"}}},
+		{name: "option rename of an exported question", exclude: []string{"Q2-*"},
+			mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"c"}}, valid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser, err := NewResponseParser(mockHarmonisationSurvey("en"), "en", true, "-", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := parser.SetColumnFilter(nil, tc.exclude); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			err = parser.SetColumnMappings([]ColumnMapping{tc.mapping})
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("should fail with error")
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package response_parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetColumnMappings configures how responses of older versions are remapped to the layout of the current
+// version. Option renames are applied before question renames of the same version. The mappings are
+// validated against the survey versions, the column filter and the free text restriction, columns removed
+// by them cannot be remapped. They have to be set after both and before adding responses.
+func (rp *ResponseParser) SetColumnMappings(mappings []ColumnMapping) error {
+	if len(rp.surveyVersions) < 1 {
+		return errors.New("no survey versions")
+	}
+	current := rp.surveyVersions[0]
+
+	byVersion := map[string][]ColumnMapping{}
+	for _, m := range mappings {
+		version, ok := findVersionPreview(m.VersionID, rp.surveyVersions)
+		if !ok {
+			return fmt.Errorf("column mapping: unknown version %s", m.VersionID)
+		}
+		question, ok := findQuestion(m.QuestionKey, version)
+		if !ok {
+			return fmt.Errorf("column mapping: question %s not found in version %s", m.QuestionKey, m.VersionID)
+		}
+		if len(m.NewKeys) < 1 {
+			return fmt.Errorf("column mapping: new keys missing for %s", m.QuestionKey)
+		}
+
+		if m.OptionKey != "" {
+			if len(m.NewKeys) != 1 {
+				return fmt.Errorf("column mapping: option %s of %s can only be renamed to one key", m.OptionKey, m.QuestionKey)
+			}
+			if !hasOption(question, m.OptionKey) {
+				return fmt.Errorf("column mapping: option %s not found in %s of version %s", m.OptionKey, m.QuestionKey, m.VersionID)
+			}
+			// the option rename is applied before the question is renamed, so the option is checked in any current question
+			found := false
+			for _, q := range current.Questions {
+				if hasOption(q, m.NewKeys[0]) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("column mapping: option %s not found in the current version", m.NewKeys[0])
+			}
+		} else {
+			for _, k := range m.NewKeys {
+				if _, ok := findQuestion(k, current); !ok {
+					return fmt.Errorf("column mapping: question %s not found in the current version", k)
+				}
+			}
+		}
+		mappedColumns := rp.mappedColumns(m, question)
+		if err := rp.checkExported(mappedColumns); err != nil {
+			return fmt.Errorf("column mapping of %s in version %s: %v", m.QuestionKey, m.VersionID, err)
+		}
+		for _, col := range mappedColumns {
+			if rp.freeTextColumns[col] {
+				return fmt.Errorf("column mapping of %s in version %s: column %s contains free text", m.QuestionKey, m.VersionID, col)
+			}
+		}
+		byVersion[version.VersionID] = append(byVersion[version.VersionID], m)
+	}
+
+	for v, ms := range byVersion {
+		optionRules := []ColumnMapping{}
+		questionRules := []ColumnMapping{}
+		for _, m := range ms {
+			if m.OptionKey != "" {
+				optionRules = append(optionRules, m)
+			} else {
+				questionRules = append(questionRules, m)
+			}
+		}
+		byVersion[v] = append(optionRules, questionRules...)
+	}
+
+	rp.columnMappings = byVersion
+	rp.currentColumns = map[string]bool{}
+	for _, q := range current.Questions {
+		for k := range getResponseColumns(q, nil, rp.questionOptionKeySep, rp.logger) {
+			rp.currentColumns[k] = true
+		}
+	}
+	return nil
+}
+
+// harmoniseColumns applies the mappings of the version to the columns of one response, values are only
+// remapped for selection columns if remapValues is set
+func (rp ResponseParser) harmoniseColumns(version SurveyVersionPreview, cols map[string]string, remapValues bool) map[string]string {
+	sep := rp.questionOptionKeySep
+	for _, m := range rp.columnMappings[version.VersionID] {
+		mapped := map[string]string{}
+		if m.OptionKey != "" {
+			question, _ := findQuestion(m.QuestionKey, version)
+			selectionCols := getSelectionColumns(question, m.OptionKey, sep)
+			for k, v := range cols {
+				if remapValues && selectionCols[k] && v == m.OptionKey {
+					v = m.NewKeys[0]
+				}
+				mapped[renameOptionColumn(k, m.QuestionKey, question, m.OptionKey, m.NewKeys[0], sep)] = v
+			}
+		} else {
+			for k, v := range cols {
+				mapped[rp.renameQuestionColumn(k, m.QuestionKey, m.NewKeys)] = v
+			}
+		}
+		cols = mapped
+	}
+	return cols
+}
+
+// mappedColumns are the columns of the question in the old version whose values are moved by the mapping
+func (rp ResponseParser) mappedColumns(m ColumnMapping, question SurveyQuestion) []string {
+	sep := rp.questionOptionKeySep
+	selectionCols := getSelectionColumns(question, m.OptionKey, sep)
+	cols := []string{}
+	for col := range getResponseColumns(question, nil, sep, rp.logger) {
+		if m.OptionKey == "" || selectionCols[col] || renameOptionColumn(col, m.QuestionKey, question, m.OptionKey, m.NewKeys[0], sep) != col {
+			cols = append(cols, col)
+		}
+	}
+	sort.Strings(cols)
+	return cols
+}
+
+// renameOptionColumn handles the column forms "Q-opt", "Q-opt-open", "Q-slot.opt" and "Q-slot.opt-open"
+func renameOptionColumn(col string, questionKey string, question SurveyQuestion, oldKey string, newKey string, sep string) string {
+	if !strings.HasPrefix(col, questionKey+sep) {
+		return col
+	}
+	rest := strings.TrimPrefix(col, questionKey+sep)
+	prefixes := []string{""}
+	for _, slot := range question.Responses {
+		prefixes = append(prefixes, slot.ID+".")
+	}
+	for _, p := range prefixes {
+		if rest == p+oldKey {
+			return questionKey + sep + p + newKey
+		}
+		if strings.HasPrefix(rest, p+oldKey+sep) {
+			return questionKey + sep + p + newKey + strings.TrimPrefix(rest, p+oldKey)
+		}
+	}
+	return col
+}
+
+// renameQuestionColumn moves a column to the first new question that has the same column in the current
+// version, otherwise to the first new question
+func (rp ResponseParser) renameQuestionColumn(col string, questionKey string, newKeys []string) string {
+	suffix := ""
+	if col != questionKey {
+		if !strings.HasPrefix(col, questionKey+rp.questionOptionKeySep) {
+			return col
+		}
+		suffix = strings.TrimPrefix(col, questionKey)
+	}
+	for _, k := range newKeys {
+		if rp.currentColumns[k+suffix] {
+			return k + suffix
+		}
+	}
+	return newKeys[0] + suffix
+}
+
+// getSelectionColumns are the columns holding the key of the selected option
+func getSelectionColumns(question SurveyQuestion, optionKey string, sep string) map[string]bool {
+	cols := map[string]bool{}
+	for _, slot := range question.Responses {
+		for _, o := range slot.Options {
+			if o.ID != optionKey {
+				continue
+			}
+			if len(question.Responses) == 1 {
+				cols[question.ID] = true
+			} else {
+				cols[question.ID+sep+slot.ID] = true
+			}
+		}
+	}
+	return cols
+}
+
+func findVersionPreview(versionID string, versions []SurveyVersionPreview) (SurveyVersionPreview, bool) {
+	for _, v := range versions {
+		if v.VersionID == versionID {
+			return v, true
+		}
+	}
+	return SurveyVersionPreview{}, false
+}
+
+func findQuestion(key string, version SurveyVersionPreview) (SurveyQuestion, bool) {
+	for _, q := range version.Questions {
+		if q.ID == key {
+			return q, true
+		}
+	}
+	return SurveyQuestion{}, false
+}
+
+func hasOption(question SurveyQuestion, optionKey string) bool {
+	for _, slot := range question.Responses {
+		for _, o := range slot.Options {
+			if o.ID == optionKey {
+				return true
+			}
+		}
+	}
+	return false
+}
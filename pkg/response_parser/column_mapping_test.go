@@ -0,0 +1,134 @@
+package response_parser
+
+import (
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func mockHarmonisationSurvey(testLang string) *studyAPI.Survey {
+	return &studyAPI.Survey{
+		Id: "surveyIDfromDB",
+		Current: &studyAPI.SurveyVersion{
+			Published: 100,
+			VersionId: "2",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key: "weekly",
+				Items: []*studyAPI.SurveyItem{
+					mockQuestion("weekly.Q1", testLang, "Title of Q1", mockSingleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "a", Role: "option", Label: "A"},
+						{Key: "c", Role: "option", Label: "C"},
+					})),
+					mockQuestion("weekly.Q2a", testLang, "Title of Q2a", mockMultipleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "x", Role: "option", Label: "X"},
+						{Key: "y", Role: "option", Label: "Y"},
+					})),
+					mockQuestion("weekly.Q2b", testLang, "Title of Q2b", mockMultipleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "z", Role: "option", Label: "Z"},
+					})),
+				},
+			},
+		},
+		History: []*studyAPI.SurveyVersion{
+			{
+				Published:   10,
+				Unpublished: 100,
+				VersionId:   "1",
+				SurveyDefinition: &studyAPI.SurveyItem{
+					Key: "weekly",
+					Items: []*studyAPI.SurveyItem{
+						mockQuestion("weekly.Q1", testLang, "Title of Q1", mockSingleChoiceGroup(testLang, []MockOpionDef{
+							{Key: "a", Role: "option", Label: "A"},
+							{Key: "b", Role: "option", Label: "B"},
+						})),
+						mockQuestion("weekly.Q2", testLang, "Title of Q2", mockMultipleChoiceGroup(testLang, []MockOpionDef{
+							{Key: "x", Role: "option", Label: "X"},
+							{Key: "y", Role: "option", Label: "Y"},
+							{Key: "z", Role: "option", Label: "Z"},
+						})),
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSetColumnMappings(t *testing.T) {
+	parser, err := NewResponseParser(mockHarmonisationSurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	testCases := []struct {
+		name    string
+		mapping ColumnMapping
+		valid   bool
+	}{
+		{name: "unknown version", mapping: ColumnMapping{VersionID: "0", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"c"}}},
+		{name: "unknown question", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q3", NewKeys: []string{"Q1"}}},
+		{name: "unknown option", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "d", NewKeys: []string{"c"}}},
+		{name: "unknown new option", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"d"}}},
+		{name: "unknown new question", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q2", NewKeys: []string{"Q2a", "Q2c"}}},
+		{name: "without new keys", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q2"}},
+		{name: "option rename", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"c"}}, valid: true},
+		{name: "question split", mapping: ColumnMapping{VersionID: "1", QuestionKey: "Q2", NewKeys: []string{"Q2a", "Q2b"}}, valid: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parser.SetColumnMappings([]ColumnMapping{tc.mapping})
+			if tc.valid && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !tc.valid && err == nil {
+				t.Error("should fail with error")
+			}
+		})
+	}
+}
+
+func TestHarmonisedColumns(t *testing.T) {
+	parser, err := NewResponseParser(mockHarmonisationSurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetColumnMappings([]ColumnMapping{
+		{VersionID: "1", QuestionKey: "Q2", NewKeys: []string{"Q2a", "Q2b"}},
+		{VersionID: "1", QuestionKey: "Q1", OptionKey: "b", NewKeys: []string{"c"}},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	oldResp := &studyAPI.SurveyResponse{Key: "weekly", ParticipantId: "p1", SubmittedAt: 50, VersionId: "1",
+		Responses: []*studyAPI.SurveyItemResponse{
+			{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: "b"}}},
+			}}},
+			{Key: "weekly.Q2", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "mcg", Items: []*studyAPI.ResponseItem{{Key: "x"}, {Key: "z"}}},
+			}}},
+		},
+	}
+	if err := parser.AddResponse(oldResp); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	r := parser.GetResponses()[0].Responses
+	expected := map[string]string{
+		"Q1":    "c",
+		"Q2a-x": TRUE_VALUE,
+		"Q2a-y": FALSE_VALUE,
+		"Q2b-z": TRUE_VALUE,
+	}
+	if len(r) != len(expected) {
+		t.Errorf("unexpected columns: %v", r)
+	}
+	for k, v := range expected {
+		if r[k] != v {
+			t.Errorf("unexpected value for %s: %s", k, r[k])
+		}
+	}
+}
@@ -0,0 +1,344 @@
+package response_parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+const (
+	renderedAtom = iota
+	renderedAnd
+	renderedOr
+)
+
+var comparisonOperators = map[string]string{
+	"eq":  "=",
+	"lt":  "<",
+	"lte": "<=",
+	"gt":  ">",
+	"gte": ">=",
+}
+
+func appendCondition(conditions []*studyAPI.Expression, condition *studyAPI.Expression) []*studyAPI.Expression {
+	res := make([]*studyAPI.Expression, len(conditions), len(conditions)+1)
+	copy(res, conditions)
+	if condition != nil {
+		res = append(res, condition)
+	}
+	return res
+}
+
+// renderConditions combines the display conditions of an item and its parents, e.g. "shown if Q1 = 2 OR Q1 = 3"
+func renderConditions(conditions []*studyAPI.Expression) string {
+	if len(conditions) < 1 {
+		return ""
+	}
+	parts := make([]string, len(conditions))
+	for i, c := range conditions {
+		text, kind := renderExpression(c)
+		if len(conditions) > 1 && kind == renderedOr {
+			text = "(" + text + ")"
+		}
+		parts[i] = text
+	}
+	return "shown if " + strings.Join(parts, " AND ")
+}
+
+// renderValidations lists the validation rules as "key (type): rule"
+func renderValidations(validations []*studyAPI.Validation) []string {
+	res := []string{}
+	for _, v := range validations {
+		if v == nil {
+			continue
+		}
+		rule, _ := renderExpression(v.Rule)
+		res = append(res, fmt.Sprintf("%s (%s): %s", v.Key, v.Type, rule))
+	}
+	return res
+}
+
+// renderExpression turns a survey engine expression into a readable form. Unknown expressions are
+// rendered as function calls.
+func renderExpression(exp *studyAPI.Expression) (string, int) {
+	if exp == nil {
+		return "", renderedAtom
+	}
+
+	switch exp.Name {
+	case "and", "or":
+		kind := renderedAnd
+		if exp.Name == "or" {
+			kind = renderedOr
+		}
+		parts := []string{}
+		for _, arg := range exp.Data {
+			text, argKind := renderArg(arg)
+			if argKind != renderedAtom && argKind != kind {
+				text = "(" + text + ")"
+			}
+			parts = append(parts, text)
+		}
+		return strings.Join(parts, " "+strings.ToUpper(exp.Name)+" "), kind
+	case "not":
+		if len(exp.Data) != 1 {
+			break
+		}
+		text, kind := renderArg(exp.Data[0])
+		if kind != renderedAtom {
+			text = "(" + text + ")"
+		}
+		return "NOT " + text, renderedAtom
+	case "eq", "lt", "lte", "gt", "gte":
+		if len(exp.Data) != 2 {
+			break
+		}
+		left, _ := renderArg(exp.Data[0])
+		right, _ := renderArg(exp.Data[1])
+		return left + " " + comparisonOperators[exp.Name] + " " + right, renderedAtom
+	case "responseHasKeysAny", "responseHasKeysAll", "responseHasOnlyKeysOtherThan":
+		if len(exp.Data) < 3 {
+			break
+		}
+		ref := renderResponseRef(exp.Data[0].GetStr(), exp.Data[1].GetStr())
+		parts := []string{}
+		for _, arg := range exp.Data[2:] {
+			op := " = "
+			if exp.Name == "responseHasOnlyKeysOtherThan" {
+				op = " != "
+			}
+			parts = append(parts, ref+op+arg.GetStr())
+		}
+		switch exp.Name {
+		case "responseHasKeysAny":
+			if len(parts) == 1 {
+				return parts[0], renderedAtom
+			}
+			return strings.Join(parts, " OR "), renderedOr
+		case "responseHasKeysAll":
+			if len(parts) == 1 {
+				return parts[0], renderedAtom
+			}
+			return strings.Join(parts, " AND "), renderedAnd
+		default:
+			return ref + " answered AND " + strings.Join(parts, " AND "), renderedAnd
+		}
+	case "isDefined":
+		if len(exp.Data) != 1 {
+			break
+		}
+		if ref := exp.Data[0].GetExp(); ref != nil && ref.Name == "getResponseItem" && len(ref.Data) == 2 {
+			return renderResponseRef(ref.Data[0].GetStr(), ref.Data[1].GetStr()) + " answered", renderedAtom
+		}
+		text, _ := renderArg(exp.Data[0])
+		return text + " is defined", renderedAtom
+	case "getResponseItem", "getResponseValueAsNum", "getResponseValueAsStr":
+		if len(exp.Data) != 2 {
+			break
+		}
+		return renderResponseRef(exp.Data[0].GetStr(), exp.Data[1].GetStr()), renderedAtom
+	}
+
+	args := make([]string, len(exp.Data))
+	for i, arg := range exp.Data {
+		args[i], _ = renderArg(arg)
+	}
+	return exp.Name + "(" + strings.Join(args, ", ") + ")", renderedAtom
+}
+
+func renderArg(arg *studyAPI.ExpressionArg) (string, int) {
+	if arg == nil {
+		return "", renderedAtom
+	}
+	switch d := arg.Data.(type) {
+	case *studyAPI.ExpressionArg_Exp:
+		return renderExpression(d.Exp)
+	case *studyAPI.ExpressionArg_Num:
+		return strconv.FormatFloat(d.Num, 'f', -1, 64), renderedAtom
+	case *studyAPI.ExpressionArg_Str:
+		return strconv.Quote(d.Str), renderedAtom
+	}
+	return "", renderedAtom
+}
+
+// renderResponseRef names a response by its item key, the slot is only added for nested response items
+func renderResponseRef(itemKey string, path string) string {
+	parts := strings.Split(path, ".")
+	if len(parts) <= 2 {
+		return itemKey
+	}
+	return itemKey + "." + strings.Join(parts[2:], ".")
+}
+
+// evalConditions checks the display conditions against the responses of the same submission. The second
+// result is false, if the conditions use expressions that cannot be evaluated from the responses.
+func evalConditions(conditions []*studyAPI.Expression, findItem func(key string) *studyAPI.SurveyItemResponse) (bool, bool) {
+	for _, c := range conditions {
+		v, ok := evalSurveyExpression(c, findItem)
+		if !ok {
+			return false, false
+		}
+		if b, isBool := v.(bool); !isBool || !b {
+			return false, isBool
+		}
+	}
+	return true, true
+}
+
+func evalSurveyExpression(exp *studyAPI.Expression, findItem func(key string) *studyAPI.SurveyItemResponse) (interface{}, bool) {
+	if exp == nil {
+		return nil, false
+	}
+
+	switch exp.Name {
+	case "and", "or":
+		for _, arg := range exp.Data {
+			v, ok := evalSurveyArg(arg, findItem)
+			if !ok {
+				return nil, false
+			}
+			b, _ := v.(bool)
+			if exp.Name == "and" && !b {
+				return false, true
+			}
+			if exp.Name == "or" && b {
+				return true, true
+			}
+		}
+		return exp.Name == "and", true
+	case "not":
+		if len(exp.Data) != 1 {
+			return nil, false
+		}
+		v, ok := evalSurveyArg(exp.Data[0], findItem)
+		if !ok {
+			return nil, false
+		}
+		b, _ := v.(bool)
+		return !b, true
+	case "eq", "lt", "lte", "gt", "gte":
+		if len(exp.Data) != 2 {
+			return nil, false
+		}
+		left, ok := evalSurveyArg(exp.Data[0], findItem)
+		if !ok {
+			return nil, false
+		}
+		right, ok := evalSurveyArg(exp.Data[1], findItem)
+		if !ok {
+			return nil, false
+		}
+		return compareSurveyValues(exp.Name, left, right), true
+	case "responseHasKeysAny", "responseHasKeysAll", "responseHasOnlyKeysOtherThan":
+		if len(exp.Data) < 3 {
+			return nil, false
+		}
+		group := retrieveResponseItem(findItem(exp.Data[0].GetStr()), exp.Data[1].GetStr())
+		if group == nil {
+			return false, true
+		}
+		selected := map[string]bool{}
+		for _, item := range group.Items {
+			selected[item.Key] = true
+		}
+		count := 0
+		for _, arg := range exp.Data[2:] {
+			if selected[arg.GetStr()] {
+				count += 1
+			}
+		}
+		switch exp.Name {
+		case "responseHasKeysAny":
+			return count > 0, true
+		case "responseHasKeysAll":
+			return count == len(exp.Data)-2, true
+		default:
+			return len(group.Items) > 0 && count == 0, true
+		}
+	case "isDefined":
+		if len(exp.Data) != 1 {
+			return nil, false
+		}
+		v, ok := evalSurveyArg(exp.Data[0], findItem)
+		if !ok {
+			return nil, false
+		}
+		return v != nil, true
+	case "getResponseItem", "getResponseValueAsNum", "getResponseValueAsStr":
+		if len(exp.Data) != 2 {
+			return nil, false
+		}
+		item := retrieveResponseItem(findItem(exp.Data[0].GetStr()), exp.Data[1].GetStr())
+		if item == nil {
+			return nil, true
+		}
+		switch exp.Name {
+		case "getResponseValueAsNum":
+			v, err := strconv.ParseFloat(item.Value, 64)
+			if err != nil {
+				return nil, true
+			}
+			return v, true
+		case "getResponseValueAsStr":
+			return item.Value, true
+		}
+		return item, true
+	}
+	return nil, false
+}
+
+func evalSurveyArg(arg *studyAPI.ExpressionArg, findItem func(key string) *studyAPI.SurveyItemResponse) (interface{}, bool) {
+	if arg == nil {
+		return nil, false
+	}
+	switch d := arg.Data.(type) {
+	case *studyAPI.ExpressionArg_Exp:
+		return evalSurveyExpression(d.Exp, findItem)
+	case *studyAPI.ExpressionArg_Num:
+		return d.Num, true
+	case *studyAPI.ExpressionArg_Str:
+		return d.Str, true
+	}
+	return nil, false
+}
+
+func compareSurveyValues(op string, left interface{}, right interface{}) bool {
+	if left == nil || right == nil {
+		return false
+	}
+	ln, lIsNum := left.(float64)
+	rn, rIsNum := right.(float64)
+	if !lIsNum || !rIsNum {
+		ls, lIsStr := left.(string)
+		rs, rIsStr := right.(string)
+		if !lIsStr || !rIsStr {
+			return false
+		}
+		switch op {
+		case "eq":
+			return ls == rs
+		case "lt":
+			return ls < rs
+		case "lte":
+			return ls <= rs
+		case "gt":
+			return ls > rs
+		default:
+			return ls >= rs
+		}
+	}
+	switch op {
+	case "eq":
+		return ln == rn
+	case "lt":
+		return ln < rn
+	case "lte":
+		return ln <= rn
+	case "gt":
+		return ln > rn
+	default:
+		return ln >= rn
+	}
+}
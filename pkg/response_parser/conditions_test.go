@@ -0,0 +1,152 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func strArg(s string) *studyAPI.ExpressionArg {
+	return &studyAPI.ExpressionArg{Dtype: "str", Data: &studyAPI.ExpressionArg_Str{Str: s}}
+}
+
+func numArg(n float64) *studyAPI.ExpressionArg {
+	return &studyAPI.ExpressionArg{Dtype: "num", Data: &studyAPI.ExpressionArg_Num{Num: n}}
+}
+
+func expArg(name string, args ...*studyAPI.ExpressionArg) *studyAPI.ExpressionArg {
+	return &studyAPI.ExpressionArg{Dtype: "exp", Data: &studyAPI.ExpressionArg_Exp{Exp: &studyAPI.Expression{Name: name, Data: args}}}
+}
+
+func TestRenderExpression(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exp      *studyAPI.ExpressionArg
+		expected string
+	}{
+		{
+			name:     "has keys any",
+			exp:      expArg("responseHasKeysAny", strArg("weekly.Q1"), strArg("rg.scg"), strArg("2"), strArg("3")),
+			expected: "weekly.Q1 = 2 OR weekly.Q1 = 3",
+		},
+		{
+			name: "and with nested or",
+			exp: expArg("and",
+				expArg("responseHasKeysAny", strArg("Q1"), strArg("rg.scg"), strArg("2"), strArg("3")),
+				expArg("gt", expArg("getResponseValueAsNum", strArg("Q2"), strArg("rg.num")), numArg(18)),
+			),
+			expected: "(Q1 = 2 OR Q1 = 3) AND Q2 > 18",
+		},
+		{
+			name:     "not answered",
+			exp:      expArg("not", expArg("isDefined", expArg("getResponseItem", strArg("Q1"), strArg("rg.mcg")))),
+			expected: "NOT Q1 answered",
+		},
+		{
+			name:     "only other keys",
+			exp:      expArg("responseHasOnlyKeysOtherThan", strArg("Q1"), strArg("rg.mcg"), strArg("0")),
+			expected: "Q1 answered AND Q1 != 0",
+		},
+		{
+			name:     "unknown expression",
+			exp:      expArg("checkEventType", strArg("ENTER")),
+			expected: "checkEventType(\"ENTER\")",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r, _ := renderArg(tc.exp); r != tc.expected {
+				t.Errorf("unexpected result: %s", r)
+			}
+		})
+	}
+}
+
+func TestRenderConditions(t *testing.T) {
+	conditions := appendCondition(nil, expArg("or",
+		expArg("responseHasKeysAny", strArg("Q1"), strArg("rg.scg"), strArg("1")),
+		expArg("responseHasKeysAny", strArg("Q1"), strArg("rg.scg"), strArg("2")),
+	).GetExp())
+	conditions = appendCondition(conditions, expArg("responseHasKeysAny", strArg("Q2"), strArg("rg.scg"), strArg("yes")).GetExp())
+	if r := renderConditions(conditions); r != "shown if (Q1 = 1 OR Q1 = 2) AND Q2 = yes" {
+		t.Errorf("unexpected result: %s", r)
+	}
+}
+
+func TestEvalConditions(t *testing.T) {
+	responses := []*studyAPI.SurveyItemResponse{
+		{Key: "Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+			{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: "2"}}},
+		}}},
+		{Key: "Q2", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+			{Key: "num", Value: "17"},
+		}}},
+	}
+	findItem := func(key string) *studyAPI.SurveyItemResponse {
+		return findResponse(responses, key)
+	}
+
+	testCases := []struct {
+		name      string
+		condition *studyAPI.ExpressionArg
+		expected  bool
+		decidable bool
+	}{
+		{name: "has key", condition: expArg("responseHasKeysAny", strArg("Q1"), strArg("rg.scg"), strArg("2"), strArg("3")), expected: true, decidable: true},
+		{name: "missing item", condition: expArg("responseHasKeysAny", strArg("Q3"), strArg("rg.scg"), strArg("2")), expected: false, decidable: true},
+		{name: "number comparison", condition: expArg("gte", expArg("getResponseValueAsNum", strArg("Q2"), strArg("rg.num")), numArg(18)), expected: false, decidable: true},
+		{name: "not defined", condition: expArg("not", expArg("isDefined", expArg("getResponseItem", strArg("Q3"), strArg("rg")))), expected: true, decidable: true},
+		{name: "unknown expression", condition: expArg("checkEventType", strArg("ENTER")), expected: false, decidable: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, ok := evalConditions(appendCondition(nil, tc.condition.GetExp()), findItem)
+			if r != tc.expected || ok != tc.decidable {
+				t.Errorf("unexpected result: %v, %v", r, ok)
+			}
+		})
+	}
+}
+
+func TestConditionsInSurveyInfo(t *testing.T) {
+	survey := mockWeeklyILISurvey("en")
+	survey.Current.SurveyDefinition.Items[1].Condition = expArg("responseHasKeysAny", strArg("weekly.Q1"), strArg("rg.mcg"), strArg("fever"), strArg("cough")).GetExp()
+
+	parser, err := NewResponseParser(survey, "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	t.Run("question condition", func(t *testing.T) {
+		q := parser.GetSurveyVersionDefs()[0].Questions[1]
+		if q.Condition != "shown if Q1 = fever OR Q1 = cough" {
+			t.Errorf("unexpected condition: %s", q.Condition)
+		}
+		if q.ToAPI().Condition != q.Condition {
+			t.Errorf("unexpected api condition: %s", q.ToAPI().Condition)
+		}
+	})
+
+	t.Run("survey info csv", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parser.GetSurveyInfoCSV(buf); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !strings.Contains(buf.String(), "shown if Q1 = fever OR Q1 = cough") {
+			t.Errorf("condition missing: %s", buf.String())
+		}
+	})
+
+	t.Run("consistency check", func(t *testing.T) {
+		_ = parser.AddResponse(mockWeeklyILIResponse("p1", 100, []string{"headache"}, "yes"))
+		_ = parser.AddResponse(mockWeeklyILIResponse("p2", 100, []string{"fever"}, "yes"))
+		report := parser.GetDataQualityReport()
+		if report.IssueCounts[QUALITY_ISSUE_HIDDEN_ANSWERED] != 1 || report.Issues[0].ParticipantID != "p1" {
+			t.Errorf("unexpected report: %v", report)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package response_parser
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const utf8BOM = "\xEF\xBB\xBF"
+
+// SetCSVDialect configures the CSV format of all CSV writers of the parser
+func (rp *ResponseParser) SetCSVDialect(d CSVDialect) error {
+	if d.Delimiter == "" {
+		d.Delimiter = ","
+	}
+	r, size := utf8.DecodeRuneInString(d.Delimiter)
+	if size != len(d.Delimiter) || r == utf8.RuneError {
+		return errors.New("delimiter must be a single character")
+	}
+	if r == '"' || r == '\r' || r == '\n' {
+		return errors.New("invalid delimiter")
+	}
+	if (&csvWriter{comma: r}).fieldNeedsQuotes(d.NullValue) {
+		return errors.New("null value must not need quotes")
+	}
+	rp.csvDialect = d
+	return nil
+}
+
+// csvWriter writes records in the configured dialect. encoding/csv is used unless all fields are quoted or
+// a null value is set, the null value is then the only unquoted value that can be equal to it.
+type csvWriter struct {
+	dialect CSVDialect
+	comma   rune
+	out     io.Writer
+	w       *csv.Writer
+	started bool
+	err     error
+}
+
+func (rp ResponseParser) newCSVWriter(writer io.Writer) *csvWriter {
+	d := rp.csvDialect
+	if d.Delimiter == "" {
+		d.Delimiter = ","
+	}
+	comma, _ := utf8.DecodeRuneInString(d.Delimiter)
+	cw := &csvWriter{
+		dialect: d,
+		comma:   comma,
+		out:     writer,
+	}
+	if !d.QuoteAll && d.NullValue == "" {
+		cw.w = csv.NewWriter(writer)
+		cw.w.Comma = comma
+		cw.w.UseCRLF = d.CRLF
+	}
+	return cw
+}
+
+// WriteHeader writes a record as it is
+func (cw *csvWriter) WriteHeader(record []string) error {
+	return cw.write(record, false)
+}
+
+// Write writes a data record, empty fields are replaced by the null value
+func (cw *csvWriter) Write(record []string) error {
+	return cw.write(record, true)
+}
+
+func (cw *csvWriter) write(record []string, isData bool) error {
+	if cw.err != nil {
+		return cw.err
+	}
+	if !cw.started {
+		cw.started = true
+		if cw.dialect.BOM {
+			if _, cw.err = io.WriteString(cw.out, utf8BOM); cw.err != nil {
+				return cw.err
+			}
+		}
+	}
+
+	original := record
+	if isData && cw.dialect.NullValue != "" {
+		replaced := make([]string, len(record))
+		for i, f := range record {
+			if f == "" {
+				f = cw.dialect.NullValue
+			}
+			replaced[i] = f
+		}
+		record = replaced
+	}
+
+	if cw.w != nil {
+		cw.err = cw.w.Write(record)
+		return cw.err
+	}
+
+	var b strings.Builder
+	for i, f := range record {
+		if i > 0 {
+			b.WriteRune(cw.comma)
+		}
+		switch {
+		case isData && cw.dialect.NullValue != "" && original[i] == "":
+			// the null value stays unquoted, so it is not read as a string
+			b.WriteString(f)
+		case cw.dialect.QuoteAll || cw.fieldNeedsQuotes(f) || (isData && f == cw.dialect.NullValue):
+			// values equal to the null value are quoted, so they can be told apart from missing values
+			b.WriteString(`"` + strings.ReplaceAll(f, `"`, `""`) + `"`)
+		default:
+			b.WriteString(f)
+		}
+	}
+	if cw.dialect.CRLF {
+		b.WriteString("\r\n")
+	} else {
+		b.WriteString("\n")
+	}
+	_, cw.err = io.WriteString(cw.out, b.String())
+	return cw.err
+}
+
+// fieldNeedsQuotes follows the rules of encoding/csv
+func (cw *csvWriter) fieldNeedsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, cw.comma) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+func (cw *csvWriter) Flush() {
+	if cw.w != nil {
+		cw.w.Flush()
+	}
+}
+
+func (cw *csvWriter) Error() error {
+	if cw.err != nil {
+		return cw.err
+	}
+	if cw.w != nil {
+		return cw.w.Error()
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package response_parser
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetCSVDialect(t *testing.T) {
+	parser := ResponseParser{}
+	for _, d := range []string{";;", "\"", "\n"} {
+		if err := parser.SetCSVDialect(CSVDialect{Delimiter: d}); err == nil {
+			t.Errorf("delimiter %q should fail with error", d)
+		}
+	}
+	for _, d := range []string{"", ";", "\t"} {
+		if err := parser.SetCSVDialect(CSVDialect{Delimiter: d}); err != nil {
+			t.Errorf("unexpected error for %q: %v", d, err)
+		}
+	}
+	for _, null := range []string{"N,A", " NA", "\"NA\""} {
+		if err := parser.SetCSVDialect(CSVDialect{NullValue: null}); err == nil {
+			t.Errorf("null value %q should fail with error", null)
+		}
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dialect  CSVDialect
+		expected string
+	}{
+		{
+			name:     "default",
+			dialect:  CSVDialect{},
+			expected: "a,b,c\n1,,\"x,y\"\n",
+		},
+		{
+			name:     "excel",
+			dialect:  CSVDialect{Delimiter: ";", BOM: true, CRLF: true},
+			expected: utf8BOM + "a;b;c\r\n1;;x,y\r\n",
+		},
+		{
+			name:     "tabs with null value",
+			dialect:  CSVDialect{Delimiter: "\t", NullValue: "NA"},
+			expected: "a\tb\tc\n1\tNA\tx,y\n",
+		},
+		{
+			name:     "null value equal to a value",
+			dialect:  CSVDialect{NullValue: "1"},
+			expected: "a,b,c\n\"1\",1,\"x,y\"\n",
+		},
+		{
+			name:     "quote all",
+			dialect:  CSVDialect{QuoteAll: true, NullValue: "NA"},
+			expected: "\"a\",\"b\",\"c\"\n\"1\",NA,\"x,y\"\n",
+		},
+		{
+			name:     "quote all with value equal to null value",
+			dialect:  CSVDialect{QuoteAll: true, NullValue: "1"},
+			expected: "\"a\",\"b\",\"c\"\n\"1\",1,\"x,y\"\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := ResponseParser{}
+			if err := parser.SetCSVDialect(tc.dialect); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			buf := new(bytes.Buffer)
+			w := parser.newCSVWriter(buf)
+			if err := w.WriteHeader([]string{"a", "b", "c"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if err := w.Write([]string{"1", "", "x,y"}); err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			w.Flush()
+			if buf.String() != tc.expected {
+				t.Errorf("unexpected output: %q", buf.String())
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestSurveyInfoCSVDialect(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCSVDialect(CSVDialect{Delimiter: ";", BOM: true}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	buf := new(bytes.Buffer)
+	if err := parser.GetSurveyInfoCSV(buf); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if !strings.HasPrefix(buf.String(), utf8BOM+"surveyKey;versionID;questionKey;") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+
+	t.Run("with write error", func(t *testing.T) {
+		if err := parser.SetCSVDialect(CSVDialect{}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := parser.GetSurveyInfoCSV(failingWriter{}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package response_parser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Deduplicator applies a dedup policy to responses of one survey as they arrive, so it can be used on
+// a response stream as well as on buffered responses. The responses of a participant have to be added in
+// the order of their submission, see isRepeatedSubmission for the definition of a duplicate.
+type Deduplicator struct {
+	policy string
+	window int64
+	groups map[string]*dedupGroup
+}
+
+type dedupGroup struct {
+	first   int64
+	last    int64
+	pending *ParsedResponse // only used by DEDUP_POLICY_KEEP_LAST
+}
+
+func NewDeduplicator(policy string, window time.Duration) (*Deduplicator, error) {
+	switch policy {
+	case DEDUP_POLICY_NONE, DEDUP_POLICY_KEEP_FIRST, DEDUP_POLICY_KEEP_LAST, DEDUP_POLICY_KEEP_ALL:
+	default:
+		return nil, fmt.Errorf("unknown dedup policy: %s", policy)
+	}
+	if window < 0 {
+		return nil, errors.New("dedup window must not be negative")
+	}
+	return &Deduplicator{
+		policy: policy,
+		window: int64(window / time.Second),
+		groups: map[string]*dedupGroup{},
+	}, nil
+}
+
+// Add returns the responses that can be written. With DEDUP_POLICY_KEEP_LAST the response of a group is
+// only released, when a later submission of the participant is outside of the window or on Flush.
+func (d *Deduplicator) Add(resp ParsedResponse) []ParsedResponse {
+	if d.policy == DEDUP_POLICY_NONE {
+		return []ParsedResponse{resp}
+	}
+
+	g, ok := d.groups[resp.ParticipantID]
+	if !ok || !d.isDuplicate(g, resp.SubmittedAt) {
+		released := []ParsedResponse{}
+		if ok && g.pending != nil {
+			released = append(released, *g.pending)
+		}
+		g = &dedupGroup{first: resp.SubmittedAt, last: resp.SubmittedAt}
+		d.groups[resp.ParticipantID] = g
+		if d.policy == DEDUP_POLICY_KEEP_LAST {
+			g.pending = &resp
+			return released
+		}
+		return append(released, resp)
+	}
+
+	g.last = resp.SubmittedAt
+	switch d.policy {
+	case DEDUP_POLICY_KEEP_LAST:
+		g.pending = &resp
+	case DEDUP_POLICY_KEEP_ALL:
+		resp.DuplicateOf = g.first
+		return []ParsedResponse{resp}
+	}
+	return []ParsedResponse{}
+}
+
+// Flush returns the responses still held back, ordered by submission time
+func (d *Deduplicator) Flush() []ParsedResponse {
+	released := []ParsedResponse{}
+	for pID, g := range d.groups {
+		if g.pending != nil {
+			released = append(released, *g.pending)
+		}
+		delete(d.groups, pID)
+	}
+	sort.SliceStable(released, func(i, j int) bool {
+		return released[i].SubmittedAt < released[j].SubmittedAt
+	})
+	return released
+}
+
+func (d *Deduplicator) isDuplicate(g *dedupGroup, submittedAt int64) bool {
+	return isRepeatedSubmission(g.last, submittedAt, d.window)
+}
+
+// isRepeatedSubmission is the definition of a duplicate shared by the dedup policies and the data-quality
+// report: a submission is a duplicate, if it is within the window (in seconds) of the previous submission
+// of the same participant. So a group of duplicates can span more than the window.
+func isRepeatedSubmission(previous int64, submittedAt int64, window int64) bool {
+	diff := submittedAt - previous
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= window
+}
+
+// sortedBySubmission returns a copy of the responses ordered by submission time, responses submitted at
+// the same time keep their order
+func sortedBySubmission(responses []ParsedResponse) []ParsedResponse {
+	sorted := make([]ParsedResponse, len(responses))
+	copy(sorted, responses)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].SubmittedAt < sorted[j].SubmittedAt
+	})
+	return sorted
+}
+
+// SetDeduplication configures the dedup policy used by GetResponses and the exports. The window is also
+// used for the duplicates of the data-quality report.
+func (rp *ResponseParser) SetDeduplication(policy string, window time.Duration) error {
+	if _, err := NewDeduplicator(policy, window); err != nil {
+		return err
+	}
+	rp.dedupPolicy = policy
+	rp.SetDuplicateWindow(window)
+	return nil
+}
+
+// dedupedResponses applies the dedup policy, the study service returns the responses in no particular
+// order, so they are sorted by submission time first
+func (rp ResponseParser) dedupedResponses() []ParsedResponse {
+	if rp.dedupPolicy == DEDUP_POLICY_NONE {
+		return rp.responses
+	}
+	d, _ := NewDeduplicator(rp.dedupPolicy, rp.duplicateWindow)
+	responses := []ParsedResponse{}
+	for _, r := range sortedBySubmission(rp.responses) {
+		responses = append(responses, d.Add(r)...)
+	}
+	return append(responses, d.Flush()...)
+}
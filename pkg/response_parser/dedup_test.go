@@ -0,0 +1,159 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDeduplicator(t *testing.T) {
+	responses := []ParsedResponse{
+		{ParticipantID: "p1", SubmittedAt: 1000},
+		{ParticipantID: "p2", SubmittedAt: 1100},
+		{ParticipantID: "p1", SubmittedAt: 1300},
+		{ParticipantID: "p1", SubmittedAt: 1800},
+		{ParticipantID: "p1", SubmittedAt: 5000},
+	}
+
+	run := func(policy string) []ParsedResponse {
+		d, err := NewDeduplicator(policy, 10*time.Minute)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return nil
+		}
+		out := []ParsedResponse{}
+		for _, r := range responses {
+			out = append(out, d.Add(r)...)
+		}
+		return append(out, d.Flush()...)
+	}
+
+	t.Run("with unknown policy", func(t *testing.T) {
+		_, err := NewDeduplicator("keepNone", time.Minute)
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("without policy", func(t *testing.T) {
+		out := run(DEDUP_POLICY_NONE)
+		if len(out) != len(responses) {
+			t.Errorf("unexpected number of responses: %d", len(out))
+		}
+	})
+
+	t.Run("keep first", func(t *testing.T) {
+		out := run(DEDUP_POLICY_KEEP_FIRST)
+		if len(out) != 3 || out[0].SubmittedAt != 1000 || out[1].SubmittedAt != 1100 || out[2].SubmittedAt != 5000 {
+			t.Errorf("unexpected responses: %v", out)
+		}
+	})
+
+	t.Run("keep last", func(t *testing.T) {
+		out := run(DEDUP_POLICY_KEEP_LAST)
+		if len(out) != 3 || out[0].SubmittedAt != 1800 || out[1].SubmittedAt != 1100 || out[2].SubmittedAt != 5000 {
+			t.Errorf("unexpected responses: %v", out)
+		}
+	})
+
+	t.Run("keep all", func(t *testing.T) {
+		out := run(DEDUP_POLICY_KEEP_ALL)
+		if len(out) != len(responses) {
+			t.Errorf("unexpected number of responses: %d", len(out))
+			return
+		}
+		expected := []int64{0, 0, 1000, 1000, 0}
+		for i, r := range out {
+			if r.DuplicateOf != expected[i] {
+				t.Errorf("unexpected duplicate_of for response %d: %d", i, r.DuplicateOf)
+			}
+		}
+	})
+}
+
+func TestDeduplicatedExport(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetDeduplication("wrong", time.Minute); err == nil {
+		t.Error("should fail with error")
+	}
+	if err := parser.SetDeduplication(DEDUP_POLICY_KEEP_ALL, 5*time.Minute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	// the study service returns the responses in no particular order
+	_ = parser.AddResponse(mockWeeklyILIResponse("p1", 1060, []string{"fever"}, "yes"))
+	_ = parser.AddResponse(mockWeeklyILIResponse("p1", 1000, []string{"fever"}, "yes"))
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasPrefix(lines[0], "participantID,version,submitted,duplicate_of,") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.HasPrefix(lines[2], "p1,1,1060,1000,") {
+		t.Errorf("unexpected line: %s", lines[2])
+	}
+
+	if err := parser.SetDeduplication(DEDUP_POLICY_KEEP_FIRST, 5*time.Minute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if responses := parser.GetResponses(); len(responses) != 1 || responses[0].SubmittedAt != 1000 {
+		t.Errorf("unexpected responses: %v", responses)
+	}
+	if err := parser.SetDeduplication(DEDUP_POLICY_KEEP_LAST, 5*time.Minute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if responses := parser.GetResponses(); len(responses) != 1 || responses[0].SubmittedAt != 1060 {
+		t.Errorf("unexpected responses: %v", responses)
+	}
+
+	t.Run("duplicate_of with timestamp format", func(t *testing.T) {
+		if err := parser.SetDeduplication(DEDUP_POLICY_KEEP_ALL, 5*time.Minute); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := parser.SetTimestampFormat(TimestampFormat{Format: TIME_FORMAT_ISO8601, Timezone: "Europe/Berlin"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		buf := new(bytes.Buffer)
+		if err := parser.GetResponsesCSV(buf, false); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if !strings.HasPrefix(lines[2], "p1,1,1970-01-01T01:17:40+01:00,1970-01-01T01:16:40+01:00,") {
+			t.Errorf("unexpected line: %s", lines[2])
+		}
+	})
+
+	t.Run("same duplicates as the quality report", func(t *testing.T) {
+		parser, _ := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+		if err := parser.SetDeduplication(DEDUP_POLICY_KEEP_FIRST, 10*time.Minute); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		// 2000 is more than the window after 1000, but a duplicate through 1500
+		for _, ts := range []int64{2000, 1000, 1500, 9000} {
+			_ = parser.AddResponse(mockWeeklyILIResponse("p1", ts, []string{"fever"}, "yes"))
+		}
+		removed := len(parser.responses) - len(parser.GetResponses())
+		if removed != 2 {
+			t.Errorf("unexpected number of removed duplicates: %d", removed)
+		}
+		if n := parser.GetDataQualityReport().IssueCounts[QUALITY_ISSUE_DUPLICATE]; n != removed {
+			t.Errorf("unexpected number of reported duplicates: %d", n)
+		}
+	})
+}
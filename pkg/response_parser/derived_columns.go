@@ -0,0 +1,74 @@
+package response_parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+type derivedColumn struct {
+	name string
+	expr *Expression
+}
+
+// SetDerivedColumns parses the expressions and checks that they only reference known columns.
+// An expression can use the response columns, the participantID, version and submitted columns,
+// the case definitions and the derived columns defined before it. Columns removed by the column filter
+// cannot be used.
+func (rp *ResponseParser) SetDerivedColumns(defs []DerivedColumnDef) error {
+	responseColumns := rp.getPossibleResponseColumns()
+	knownColumns := map[string]bool{"participantID": true, "version": true, "submitted": true}
+	for c := range responseColumns {
+		knownColumns[c] = true
+	}
+	for _, cd := range rp.caseDefinitions {
+		knownColumns[cd.Name] = true
+	}
+
+	derivedColumns := []derivedColumn{}
+	for _, def := range defs {
+		if def.Name == "" {
+			return errors.New("derived column name missing")
+		}
+		if knownColumns[def.Name] {
+			return fmt.Errorf("derived column %s: name already used", def.Name)
+		}
+		expr, err := ParseExpression(def.Expression)
+		if err != nil {
+			return fmt.Errorf("derived column %s: %v", def.Name, err)
+		}
+		for _, col := range expr.Columns() {
+			if !knownColumns[col] {
+				return fmt.Errorf("derived column %s: unknown column %s", def.Name, col)
+			}
+			// case definitions and derived columns are checked on their own
+			if !responseColumns[col] {
+				continue
+			}
+			if err := rp.checkExported([]string{col}); err != nil {
+				return fmt.Errorf("derived column %s: %v", def.Name, err)
+			}
+		}
+		knownColumns[def.Name] = true
+		derivedColumns = append(derivedColumns, derivedColumn{name: def.Name, expr: expr})
+	}
+	rp.derivedColumns = derivedColumns
+	return nil
+}
+
+func (pr ParsedResponse) lookupColumn(column string) (string, bool) {
+	if v, ok := pr.Derived[column]; ok {
+		return v, true
+	}
+	if v, ok := pr.Responses[column]; ok {
+		return v, true
+	}
+	switch column {
+	case "participantID":
+		return pr.ParticipantID, true
+	case "version":
+		return pr.Version, true
+	case "submitted":
+		return fmt.Sprint(pr.SubmittedAt), true
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package response_parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetDerivedColumns(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	t.Run("with missing name", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Expression: "Q2"}})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with name of existing column", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Name: "ili", Expression: "Q2"}})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with syntax error", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Name: "test", Expression: "Q2 ="}})
+		if err == nil || !strings.Contains(err.Error(), "test") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with unknown column", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{{Name: "test", Expression: "`Q1-fever` OR Q9"}})
+		if err == nil || !strings.Contains(err.Error(), "unknown column Q9") {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("with reference to a later derived column", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{
+			{Name: "a", Expression: "b"},
+			{Name: "b", Expression: "Q2"},
+		})
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with valid definitions", func(t *testing.T) {
+		err := parser.SetDerivedColumns([]DerivedColumnDef{
+			{Name: "respiratory", Expression: "`Q1-cough` OR `Q1-sorethroat` OR `Q1-breath`"},
+			{Name: "ili_respiratory", Expression: "ili AND respiratory"},
+			{Name: "year", Expression: "year(submitted)"},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestDerivedColumnValues(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetDerivedColumns([]DerivedColumnDef{
+		{Name: "respiratory", Expression: "`Q1-cough` OR `Q1-sorethroat` OR `Q1-breath`"},
+		{Name: "not_ili_but_respiratory", Expression: "NOT ili AND respiratory"},
+		{Name: "year", Expression: "year(submitted)"},
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	submitted := time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC).Unix()
+	if err := parser.AddResponse(mockWeeklyILIResponse("p1", submitted, []string{"cough"}, "yes")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	r := parser.GetResponses()[0]
+	if r.Derived["ili"] != FALSE_VALUE ||
+		r.Derived["respiratory"] != TRUE_VALUE ||
+		r.Derived["not_ili_but_respiratory"] != TRUE_VALUE ||
+		r.Derived["year"] != "2021" {
+		t.Errorf("unexpected derived values: %v", r.Derived)
+	}
+	if strings.Join(parser.derivedColNames, ",") != "ili,respiratory,not_ili_but_respiratory,year" {
+		t.Errorf("unexpected column order: %v", parser.derivedColNames)
+	}
+}
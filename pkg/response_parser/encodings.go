@@ -0,0 +1,83 @@
+package response_parser
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// SetBooleanEncoding configures the values written for boolean columns. Internally TRUE_VALUE and
+// FALSE_VALUE are kept, so case definitions and statistics are not affected. Empty values keep the default.
+func (rp *ResponseParser) SetBooleanEncoding(be BooleanEncoding) error {
+	if be.True == "" {
+		be.True = TRUE_VALUE
+	}
+	if be.False == "" {
+		be.False = FALSE_VALUE
+	}
+	if be.True == be.False {
+		return errors.New("boolean values must be different")
+	}
+	rp.booleanEncoding = be
+
+	rp.booleanColumns = map[string]bool{}
+	for _, sv := range rp.surveyVersions {
+		for _, q := range sv.Questions {
+			for _, k := range getBooleanColumns(q, rp.questionOptionKeySep) {
+				rp.booleanColumns[k] = true
+			}
+		}
+	}
+	return nil
+}
+
+// SetArrayEncoding configures how lists like the meta timestamps are written, it has to be set before
+// adding responses
+func (rp *ResponseParser) SetArrayEncoding(ae ArrayEncoding) {
+	if ae.Separator == "" {
+		ae.Separator = ";"
+	}
+	rp.arrayEncoding = ae
+}
+
+func (rp ResponseParser) encodeBoolean(v string) string {
+	switch v {
+	case TRUE_VALUE:
+		return rp.booleanEncoding.True
+	case FALSE_VALUE:
+		return rp.booleanEncoding.False
+	}
+	return v
+}
+
+func (rp ResponseParser) encodeArray(values []string, quote bool) string {
+	if !rp.arrayEncoding.JSON {
+		return strings.Join(values, rp.arrayEncoding.Separator)
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	if !quote {
+		return "[" + strings.Join(values, ",") + "]"
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
+
+// getBooleanColumns lists the option columns of multiple choice slots
+func getBooleanColumns(question SurveyQuestion, sep string) []string {
+	cols := []string{}
+	for _, slot := range question.Responses {
+		if slot.ResponseType != QUESTION_TYPE_MULTIPLE_CHOICE {
+			continue
+		}
+		for _, o := range slot.Options {
+			if len(question.Responses) == 1 {
+				cols = append(cols, question.ID+sep+o.ID)
+			} else {
+				cols = append(cols, question.ID+sep+slot.ID+"."+o.ID)
+			}
+		}
+	}
+	return cols
+}
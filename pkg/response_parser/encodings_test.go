@@ -0,0 +1,99 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestSetBooleanEncoding(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	t.Run("with same values", func(t *testing.T) {
+		if err := parser.SetBooleanEncoding(BooleanEncoding{True: "1", False: "1"}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("boolean columns", func(t *testing.T) {
+		if err := parser.SetBooleanEncoding(BooleanEncoding{True: "1", False: "0"}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if !parser.booleanColumns["Q1-fever"] || parser.booleanColumns["Q2"] {
+			t.Errorf("unexpected boolean columns: %v", parser.booleanColumns)
+		}
+	})
+}
+
+func TestEncodeArray(t *testing.T) {
+	parser := ResponseParser{arrayEncoding: ArrayEncoding{Separator: "|"}}
+	if r := parser.encodeArray([]string{"1", "2"}, false); r != "1|2" {
+		t.Errorf("unexpected result: %s", r)
+	}
+
+	parser.arrayEncoding.JSON = true
+	if r := parser.encodeArray([]string{"1", "2"}, false); r != "[1,2]" {
+		t.Errorf("unexpected result: %s", r)
+	}
+	if r := parser.encodeArray([]string{"a", "b"}, true); r != `["a","b"]` {
+		t.Errorf("unexpected result: %s", r)
+	}
+	if r := parser.encodeArray([]string{}, true); r != "" {
+		t.Errorf("unexpected result: %s", r)
+	}
+}
+
+func TestEncodedExport(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.SetCaseDefinitions([]CaseDefinition{testILICaseDefinition}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if err := parser.SetBooleanEncoding(BooleanEncoding{True: "yes", False: "no"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	parser.SetArrayEncoding(ArrayEncoding{JSON: true})
+
+	resp := mockWeeklyILIResponse("p1", 100, []string{"fever", "cough"}, "yes")
+	resp.Responses[0].Meta = &studyAPI.ResponseMeta{Displayed: []int64{10, 20}}
+	if err := parser.AddResponse(resp); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	header := strings.Split(lines[0], ",")
+	cols := strings.Split(lines[1], ",")
+	values := map[string]string{}
+	for i, h := range header {
+		if i < len(cols) {
+			values[h] = cols[i]
+		}
+	}
+	if values["Q1-fever"] != "yes" || values["Q1-malaise"] != "no" || values["Q2"] != "yes" || values["ili"] != "yes" {
+		t.Errorf("unexpected values: %v", values)
+	}
+	if !strings.Contains(lines[1], `"[10,20]"`) {
+		t.Errorf("unexpected meta timestamps: %s", lines[1])
+	}
+	if parser.GetResponses()[0].Responses["Q1-fever"] != TRUE_VALUE {
+		t.Error("encoding should only be applied to the export")
+	}
+}
@@ -0,0 +1,632 @@
+package response_parser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Expression language for derived columns, e.g.:
+//   year(submitted) - Q2
+//   Q1.a OR Q1.b
+//   `Q1-a` AND NOT (Q3 = "2" OR Q3 = "3")
+// Column names containing other characters than letters, digits, '_' and '.' must be quoted with backticks.
+
+const (
+	exprMissing = iota
+	exprBool
+	exprNumber
+	exprString
+)
+
+type exprValue struct {
+	kind int
+	b    bool
+	n    float64
+	s    string
+}
+
+// exprEnv resolves column values for a single response
+type exprEnv struct {
+	lookup func(column string) (string, bool)
+	// timezone of the date functions, the export timezone
+	location *time.Location
+}
+
+type exprNode interface {
+	eval(env exprEnv) exprValue
+	columns() []string
+}
+
+type Expression struct {
+	source string
+	root   exprNode
+}
+
+// ParseExpression parses the source into an expression that can be evaluated for each response
+func ParseExpression(source string) (*Expression, error) {
+	tokens, err := tokenizeExpression(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &exprParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", p.peek().text, p.peek().pos)
+	}
+	return &Expression{source: source, root: root}, nil
+}
+
+// Columns returns the names of all columns referenced by the expression
+func (e *Expression) Columns() []string {
+	return e.root.columns()
+}
+
+func (e *Expression) evaluate(env exprEnv) string {
+	return e.root.eval(env).String()
+}
+
+func (v exprValue) String() string {
+	switch v.kind {
+	case exprBool:
+		if v.b {
+			return TRUE_VALUE
+		}
+		return FALSE_VALUE
+	case exprNumber:
+		return strconv.FormatFloat(v.n, 'f', -1, 64)
+	case exprString:
+		return v.s
+	default:
+		return ""
+	}
+}
+
+func (v exprValue) toBool() bool {
+	switch v.kind {
+	case exprBool:
+		return v.b
+	case exprNumber:
+		return v.n != 0
+	case exprString:
+		return v.s != ""
+	default:
+		return false
+	}
+}
+
+func (v exprValue) toNumber() (float64, bool) {
+	switch v.kind {
+	case exprNumber:
+		return v.n, true
+	case exprBool:
+		if v.b {
+			return 1, true
+		}
+		return 0, true
+	case exprString:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v.s), 64)
+		if err != nil {
+			return 0, false
+		}
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+func columnValueToExpr(v string) exprValue {
+	switch v {
+	case "":
+		return exprValue{kind: exprMissing}
+	case TRUE_VALUE:
+		return exprValue{kind: exprBool, b: true}
+	case FALSE_VALUE:
+		return exprValue{kind: exprBool, b: false}
+	default:
+		return exprValue{kind: exprString, s: v}
+	}
+}
+
+func numberValue(n float64) exprValue {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return exprValue{kind: exprMissing}
+	}
+	return exprValue{kind: exprNumber, n: n}
+}
+
+// Lexer
+
+const (
+	tokenEOF = iota
+	tokenNumber
+	tokenString
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type exprToken struct {
+	kind int
+	text string
+	pos  int
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+func tokenizeExpression(source string) ([]exprToken, error) {
+	tokens := []exprToken{}
+	runes := []rune(source)
+	i := 0
+	for i < len(runes) {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		case isIdentStart(r):
+			start := i
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, exprToken{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case r == '`' || r == '"':
+			start := i
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated %c at position %d", r, start)
+			}
+			kind := tokenString
+			if r == '`' {
+				kind = tokenIdent
+			}
+			tokens = append(tokens, exprToken{kind: kind, text: string(runes[start+1 : i]), pos: start})
+			i++
+		case r == '(':
+			tokens = append(tokens, exprToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, exprToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, exprToken{kind: tokenComma, text: ",", pos: i})
+			i++
+		default:
+			op := ""
+			if i+1 < len(runes) {
+				switch string(runes[i : i+2]) {
+				case "==", "!=", "<=", ">=", "&&", "||":
+					op = string(runes[i : i+2])
+				}
+			}
+			if op == "" && strings.ContainsRune("=<>+-*/!", r) {
+				op = string(r)
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected character %c at position %d", r, i)
+			}
+			tokens = append(tokens, exprToken{kind: tokenOperator, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	tokens = append(tokens, exprToken{kind: tokenEOF, text: "end of expression", pos: len(runes)})
+	return tokens, nil
+}
+
+// Parser (recursive descent, lowest precedence first)
+
+type exprParser struct {
+	tokens []exprToken
+	pos    int
+}
+
+func (p *exprParser) peek() exprToken {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) next() exprToken {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *exprParser) isKeyword(keyword string, symbol string) bool {
+	t := p.peek()
+	return (t.kind == tokenIdent && strings.EqualFold(t.text, keyword)) ||
+		(t.kind == tokenOperator && t.text == symbol)
+}
+
+func (p *exprParser) parseOr() (exprNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("OR", "||") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "OR", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAnd() (exprNode, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isKeyword("AND", "&&") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "AND", left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseNot() (exprNode, error) {
+	if p.isKeyword("NOT", "!") {
+		p.next()
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "NOT", operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *exprParser) parseComparison() (exprNode, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind == tokenOperator {
+		switch t.text {
+		case "=", "==", "!=", "<", "<=", ">", ">=":
+			p.next()
+			right, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			op := t.text
+			if op == "==" {
+				op = "="
+			}
+			return &binaryNode{op: op, left: left, right: right}, nil
+		}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseAdditive() (exprNode, error) {
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && (p.peek().text == "+" || p.peek().text == "-") {
+		op := p.next().text
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseMultiplicative() (exprNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOperator && (p.peek().text == "*" || p.peek().text == "/") {
+		op := p.next().text
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *exprParser) parseUnary() (exprNode, error) {
+	if p.peek().kind == tokenOperator && p.peek().text == "-" {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "-", operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *exprParser) parsePrimary() (exprNode, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		n, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s at position %d", t.text, t.pos)
+		}
+		return &literalNode{value: exprValue{kind: exprNumber, n: n}}, nil
+	case tokenString:
+		return &literalNode{value: exprValue{kind: exprString, s: t.text}}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next().kind != tokenRParen {
+			return nil, fmt.Errorf("missing ) for ( at position %d", t.pos)
+		}
+		return inner, nil
+	case tokenIdent:
+		if strings.EqualFold(t.text, "TRUE") {
+			return &literalNode{value: exprValue{kind: exprBool, b: true}}, nil
+		}
+		if strings.EqualFold(t.text, "FALSE") {
+			return &literalNode{value: exprValue{kind: exprBool, b: false}}, nil
+		}
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(t)
+		}
+		return &columnNode{name: t.text}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %s at position %d", t.text, t.pos)
+	}
+}
+
+func (p *exprParser) parseCall(name exprToken) (exprNode, error) {
+	fn, ok := exprFunctions[strings.ToLower(name.text)]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %s at position %d", name.text, name.pos)
+	}
+	p.next() // (
+	args := []exprNode{}
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if p.next().kind != tokenRParen {
+		return nil, fmt.Errorf("missing ) for function %s at position %d", name.text, name.pos)
+	}
+	if len(args) != fn.argCount {
+		return nil, fmt.Errorf("function %s expects %d arguments, got %d", name.text, fn.argCount, len(args))
+	}
+	return &callNode{fn: fn, args: args}, nil
+}
+
+// AST nodes
+
+type literalNode struct {
+	value exprValue
+}
+
+func (n *literalNode) eval(env exprEnv) exprValue {
+	return n.value
+}
+
+func (n *literalNode) columns() []string {
+	return []string{}
+}
+
+type columnNode struct {
+	name string
+}
+
+func (n *columnNode) eval(env exprEnv) exprValue {
+	v, ok := env.lookup(n.name)
+	if !ok {
+		return exprValue{kind: exprMissing}
+	}
+	return columnValueToExpr(v)
+}
+
+func (n *columnNode) columns() []string {
+	return []string{n.name}
+}
+
+type unaryNode struct {
+	op      string
+	operand exprNode
+}
+
+func (n *unaryNode) eval(env exprEnv) exprValue {
+	v := n.operand.eval(env)
+	if n.op == "NOT" {
+		return exprValue{kind: exprBool, b: !v.toBool()}
+	}
+	num, ok := v.toNumber()
+	if !ok {
+		return exprValue{kind: exprMissing}
+	}
+	return numberValue(-num)
+}
+
+func (n *unaryNode) columns() []string {
+	return n.operand.columns()
+}
+
+type binaryNode struct {
+	op    string
+	left  exprNode
+	right exprNode
+}
+
+func (n *binaryNode) eval(env exprEnv) exprValue {
+	switch n.op {
+	case "AND":
+		return exprValue{kind: exprBool, b: n.left.eval(env).toBool() && n.right.eval(env).toBool()}
+	case "OR":
+		return exprValue{kind: exprBool, b: n.left.eval(env).toBool() || n.right.eval(env).toBool()}
+	}
+
+	l := n.left.eval(env)
+	r := n.right.eval(env)
+	switch n.op {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return exprValue{kind: exprBool, b: compareValues(n.op, l, r)}
+	}
+
+	ln, lok := l.toNumber()
+	rn, rok := r.toNumber()
+	if !lok || !rok {
+		return exprValue{kind: exprMissing}
+	}
+	switch n.op {
+	case "+":
+		return numberValue(ln + rn)
+	case "-":
+		return numberValue(ln - rn)
+	case "*":
+		return numberValue(ln * rn)
+	case "/":
+		if rn == 0 {
+			return exprValue{kind: exprMissing}
+		}
+		return numberValue(ln / rn)
+	}
+	return exprValue{kind: exprMissing}
+}
+
+func (n *binaryNode) columns() []string {
+	return append(n.left.columns(), n.right.columns()...)
+}
+
+func compareValues(op string, l exprValue, r exprValue) bool {
+	ln, lok := l.toNumber()
+	rn, rok := r.toNumber()
+	if lok && rok {
+		switch op {
+		case "=":
+			return ln == rn
+		case "!=":
+			return ln != rn
+		case "<":
+			return ln < rn
+		case "<=":
+			return ln <= rn
+		case ">":
+			return ln > rn
+		case ">=":
+			return ln >= rn
+		}
+	}
+
+	switch op {
+	case "=":
+		return l.String() == r.String()
+	case "!=":
+		return l.String() != r.String()
+	}
+	// ordering is only defined for numbers
+	return false
+}
+
+type exprFunction struct {
+	argCount int
+	apply    func(args []exprValue, env exprEnv) exprValue
+}
+
+func timestampFunction(part func(t time.Time) int) exprFunction {
+	return exprFunction{
+		argCount: 1,
+		apply: func(args []exprValue, env exprEnv) exprValue {
+			ts, ok := args[0].toNumber()
+			if !ok {
+				return exprValue{kind: exprMissing}
+			}
+			loc := env.location
+			if loc == nil {
+				loc = time.UTC
+			}
+			return numberValue(float64(part(time.Unix(int64(ts), 0).In(loc))))
+		},
+	}
+}
+
+var exprFunctions = map[string]exprFunction{
+	"year":  timestampFunction(func(t time.Time) int { return t.Year() }),
+	"month": timestampFunction(func(t time.Time) int { return int(t.Month()) }),
+	"isoweek": timestampFunction(func(t time.Time) int {
+		_, w := t.ISOWeek()
+		return w
+	}),
+	"hasvalue": {
+		argCount: 1,
+		apply: func(args []exprValue, env exprEnv) exprValue {
+			return exprValue{kind: exprBool, b: args[0].kind != exprMissing}
+		},
+	},
+	"if": {
+		argCount: 3,
+		apply: func(args []exprValue, env exprEnv) exprValue {
+			if args[0].toBool() {
+				return args[1]
+			}
+			return args[2]
+		},
+	},
+}
+
+type callNode struct {
+	fn   exprFunction
+	args []exprNode
+}
+
+func (n *callNode) eval(env exprEnv) exprValue {
+	values := make([]exprValue, len(n.args))
+	for i, a := range n.args {
+		values[i] = a.eval(env)
+	}
+	return n.fn.apply(values, env)
+}
+
+func (n *callNode) columns() []string {
+	cols := []string{}
+	for _, a := range n.args {
+		cols = append(cols, a.columns()...)
+	}
+	return cols
+}
@@ -0,0 +1,105 @@
+package response_parser
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseExpression(t *testing.T) {
+	t.Run("with syntax errors", func(t *testing.T) {
+		for _, source := range []string{
+			"",
+			"Q1 AND",
+			"(Q1 OR Q2",
+			"Q1 # Q2",
+			"\"unterminated",
+			"unknownFn(Q1)",
+			"year(Q1, Q2)",
+			"Q1 Q2",
+		} {
+			_, err := ParseExpression(source)
+			if err == nil {
+				t.Errorf("expected error for: %s", source)
+			}
+		}
+	})
+
+	t.Run("with column references", func(t *testing.T) {
+		expr, err := ParseExpression("year(submitted) - Q2 > 18 AND (Q1.a OR `Q1-b`)")
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		cols := strings.Join(expr.Columns(), ",")
+		if cols != "submitted,Q2,Q1.a,Q1-b" {
+			t.Errorf("unexpected columns: %s", cols)
+		}
+	})
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	columns := map[string]string{
+		"submitted": "1612346400", // 2021-02-03 10:00:00 UTC
+		"Q2":        "1980",
+		"Q1.a":      TRUE_VALUE,
+		"Q1.b":      FALSE_VALUE,
+		"Q1-c":      "",
+		"Q3":        "yes",
+	}
+	env := exprEnv{lookup: func(column string) (string, bool) {
+		v, ok := columns[column]
+		return v, ok
+	}}
+
+	testCases := []struct {
+		source   string
+		expected string
+	}{
+		{source: "year(submitted) - Q2", expected: "41"},
+		{source: "month(submitted)", expected: "2"},
+		{source: "isoweek(submitted)", expected: "5"},
+		{source: "Q1.a OR Q1.b", expected: TRUE_VALUE},
+		{source: "Q1.a AND Q1.b", expected: FALSE_VALUE},
+		{source: "Q1.a && !Q1.b", expected: TRUE_VALUE},
+		{source: "not `Q1-c`", expected: TRUE_VALUE},
+		{source: "hasValue(`Q1-c`)", expected: FALSE_VALUE},
+		{source: "Q3 = \"yes\"", expected: TRUE_VALUE},
+		{source: "Q3 != \"yes\"", expected: FALSE_VALUE},
+		{source: "Q2 >= 1980", expected: TRUE_VALUE},
+		{source: "1 + 2 * 3", expected: "7"},
+		{source: "(1 + 2) * 3", expected: "9"},
+		{source: "-Q2 / 2", expected: "-990"},
+		{source: "Q2 / 0", expected: ""},
+		{source: "`Q1-c` + 1", expected: ""},
+		{source: "Q3 + 1", expected: ""},
+		{source: "if(Q1.a, \"case\", \"no case\")", expected: "case"},
+		{source: "unknownColumn", expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.source, func(t *testing.T) {
+			expr, err := ParseExpression(tc.source)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if v := expr.evaluate(env); v != tc.expected {
+				t.Errorf("unexpected result: %s", v)
+			}
+		})
+	}
+
+	t.Run("in export timezone", func(t *testing.T) {
+		loc, _ := time.LoadLocation("Europe/Berlin")
+		env := exprEnv{
+			lookup: func(column string) (string, bool) {
+				return "1612135800", true // 2021-01-31 23:30:00 UTC, a Monday in Berlin
+			},
+			location: loc,
+		}
+		expr, _ := ParseExpression("isoweek(submitted) * 100 + month(submitted)")
+		if v := expr.evaluate(env); v != "502" {
+			t.Errorf("unexpected result: %s", v)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package response_parser
+
+import studyAPI "github.com/influenzanet/study-service/pkg/api"
+
+// SetMissingValueCoding configures the codes written to the exports instead of empty response columns,
+// it has to be set before adding responses. Without coding, empty columns are exported as empty strings.
+func (rp *ResponseParser) SetMissingValueCoding(coding *MissingValueCoding) {
+	rp.missingValueCoding = coding
+}
+
+// getMissingReason tells why the columns of a question are empty, based on the item meta
+func getMissingReason(resp *studyAPI.SurveyItemResponse) string {
+	if resp == nil {
+		return MISSING_NOT_SHOWN
+	}
+	if resp.Response == nil {
+		if resp.Meta != nil && len(resp.Meta.Displayed) == 0 {
+			return MISSING_NOT_SHOWN
+		}
+		return MISSING_SKIPPED
+	}
+	return MISSING_ANSWERED_EMPTY
+}
+
+func (c MissingValueCoding) code(reason string) string {
+	switch reason {
+	case MISSING_NOT_IN_VERSION:
+		return c.NotInVersion
+	case MISSING_NOT_SHOWN:
+		return c.NotShown
+	case MISSING_SKIPPED:
+		return c.Skipped
+	case MISSING_ANSWERED_EMPTY:
+		return c.AnsweredEmpty
+	}
+	return ""
+}
+
+// exportValue is the value written for a response column, columns without a reason are not part of the version
+func (rp ResponseParser) exportValue(resp ParsedResponse, colName string) string {
+	v := resp.Responses[colName]
+	if v != "" {
+		if rp.booleanColumns[colName] {
+			return rp.encodeBoolean(v)
+		}
+		if rp.optionLabels != nil {
+			v = rp.optionLabel(colName, v)
+		}
+		return rp.formatDateInput(colName, v)
+	}
+	if rp.missingValueCoding == nil {
+		return v
+	}
+	reason, ok := resp.Missing[colName]
+	if !ok {
+		reason = MISSING_NOT_IN_VERSION
+	}
+	return rp.missingValueCoding.code(reason)
+}
@@ -0,0 +1,114 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetMissingReason(t *testing.T) {
+	testCases := []struct {
+		name     string
+		resp     *studyAPI.SurveyItemResponse
+		expected string
+	}{
+		{name: "without item", resp: nil, expected: MISSING_NOT_SHOWN},
+		{name: "never displayed", resp: &studyAPI.SurveyItemResponse{Key: "Q1", Meta: &studyAPI.ResponseMeta{Rendered: []int64{10}}}, expected: MISSING_NOT_SHOWN},
+		{name: "displayed without response", resp: &studyAPI.SurveyItemResponse{Key: "Q1", Meta: &studyAPI.ResponseMeta{Displayed: []int64{10}}}, expected: MISSING_SKIPPED},
+		{name: "without meta and response", resp: &studyAPI.SurveyItemResponse{Key: "Q1"}, expected: MISSING_SKIPPED},
+		{name: "with response", resp: &studyAPI.SurveyItemResponse{Key: "Q1", Response: &studyAPI.ResponseItem{Key: "rg"}}, expected: MISSING_ANSWERED_EMPTY},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if r := getMissingReason(tc.resp); r != tc.expected {
+				t.Errorf("unexpected reason: %s", r)
+			}
+		})
+	}
+}
+
+func TestMissingValueCodingExport(t *testing.T) {
+	survey := mockWeeklyILISurvey("en")
+	survey.Current.VersionId = "2"
+	survey.Current.Published = 100
+	survey.History = []*studyAPI.SurveyVersion{
+		{
+			Published:   10,
+			Unpublished: 100,
+			VersionId:   "1",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key:   "weekly",
+				Items: survey.Current.SurveyDefinition.Items[:1],
+			},
+		},
+	}
+
+	parser, err := NewResponseParser(survey, "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	parser.SetMissingValueCoding(&MissingValueCoding{
+		NotInVersion:  "NA",
+		NotShown:      "-99",
+		Skipped:       "-98",
+		AnsweredEmpty: "-97",
+	})
+
+	v1 := mockWeeklyILIResponse("p1", 50, []string{"fever"}, "yes")
+	v1.VersionId = "1"
+	v1.Responses = v1.Responses[:1]
+
+	notShown := mockWeeklyILIResponse("p2", 150, []string{}, "yes")
+	notShown.VersionId = "2"
+	notShown.Responses = notShown.Responses[:1]
+
+	skipped := mockWeeklyILIResponse("p3", 150, []string{"fever"}, "yes")
+	skipped.VersionId = "2"
+	skipped.Responses[1] = &studyAPI.SurveyItemResponse{Key: "weekly.Q2", Meta: &studyAPI.ResponseMeta{Displayed: []int64{140}}}
+
+	for _, r := range []*studyAPI.SurveyResponse{v1, notShown, skipped} {
+		if err := parser.AddResponse(r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	header := strings.Split(lines[0], ",")
+	colIndex := func(name string) int {
+		for i, h := range header {
+			if h == name {
+				return i
+			}
+		}
+		t.Errorf("column %s missing", name)
+		return 0
+	}
+
+	expected := []struct {
+		q1Fever string
+		q2      string
+	}{
+		{q1Fever: TRUE_VALUE, q2: "NA"},
+		{q1Fever: "-97", q2: "-99"},
+		{q1Fever: TRUE_VALUE, q2: "-98"},
+	}
+	for i, e := range expected {
+		cols := strings.Split(lines[i+1], ",")
+		if cols[colIndex("Q1-fever")] != e.q1Fever || cols[colIndex("Q2")] != e.q2 {
+			t.Errorf("unexpected line %d: %s", i, lines[i+1])
+		}
+	}
+
+	if parser.GetResponses()[1].Responses["Q2"] != "" {
+		t.Error("coding should only be applied to the export")
+	}
+}
@@ -0,0 +1,42 @@
+package response_parser
+
+// SetOptionLabels writes the labels of the selected options instead of their keys for single choice, dropdown
+// and likert columns. The labels are taken in the preview language of the parser, from the newest version
+// that contains the option.
+func (rp *ResponseParser) SetOptionLabels(useLabels bool) {
+	rp.optionLabels = nil
+	if !useLabels {
+		return
+	}
+	rp.optionLabels = map[string]map[string]string{}
+	for _, sv := range rp.surveyVersions {
+		for _, q := range sv.Questions {
+			if q.QuestionType != QUESTION_TYPE_SINGLE_CHOICE &&
+				q.QuestionType != QUESTION_TYPE_DROPDOWN &&
+				q.QuestionType != QUESTION_TYPE_LIKERT {
+				continue
+			}
+			for _, slot := range q.Responses {
+				col := q.ID
+				if len(q.Responses) > 1 {
+					col = q.ID + rp.questionOptionKeySep + slot.ID
+				}
+				if rp.optionLabels[col] == nil {
+					rp.optionLabels[col] = map[string]string{}
+				}
+				for _, o := range slot.Options {
+					if _, ok := rp.optionLabels[col][o.ID]; !ok && o.Label != "" {
+						rp.optionLabels[col][o.ID] = o.Label
+					}
+				}
+			}
+		}
+	}
+}
+
+func (rp ResponseParser) optionLabel(colName string, value string) string {
+	if label, ok := rp.optionLabels[colName][value]; ok {
+		return label
+	}
+	return value
+}
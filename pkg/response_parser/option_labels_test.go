@@ -0,0 +1,40 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestOptionLabels(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("de"), "de", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	parser.SetOptionLabels(true)
+	if err := parser.AddResponse(mockWeeklyILIResponse("p1", 100, []string{"fever"}, "yes")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	if err := parser.AddResponse(mockWeeklyILIResponse("p2", 100, []string{"fever"}, "unknown")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.HasSuffix(lines[1], ",Yes") {
+		t.Errorf("label expected: %s", lines[1])
+	}
+	if !strings.HasSuffix(lines[2], ",unknown") {
+		t.Errorf("unknown options should keep the key: %s", lines[2])
+	}
+	if parser.GetResponses()[0].Responses["Q2"] != "yes" {
+		t.Error("labels should only be applied to the export")
+	}
+}
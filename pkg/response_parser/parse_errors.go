@@ -0,0 +1,34 @@
+package response_parser
+
+import (
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
+)
+
+const (
+	PARSE_ERROR_VERSION_NOT_FOUND         = "version_not_found"
+	PARSE_ERROR_UNEXPECTED_RESPONSE_GROUP = "unexpected_response_group"
+)
+
+var parseErrorHook func(kind string)
+
+// SetParseErrorHook sets a function that is called for every parsing problem, e.g. to count them.
+// It has to be set before responses are parsed.
+func SetParseErrorHook(hook func(kind string)) {
+	parseErrorHook = hook
+}
+
+func reportParseError(kind string) {
+	if parseErrorHook != nil {
+		parseErrorHook(kind)
+	}
+}
+
+func logUnexpectedResponseGroup(logger *zap.Logger, questionKey string, rGroup *studyAPI.ResponseItem) {
+	logger.Warn("unexpected response group",
+		zap.String("question", questionKey),
+		zap.String("group", rGroup.Key),
+		zap.Int("items", len(rGroup.Items)),
+	)
+	reportParseError(PARSE_ERROR_UNEXPECTED_RESPONSE_GROUP)
+}
@@ -0,0 +1,30 @@
+package response_parser
+
+import (
+	"testing"
+)
+
+func TestParseErrorHook(t *testing.T) {
+	counts := map[string]int{}
+	SetParseErrorHook(func(kind string) {
+		counts[kind]++
+	})
+	defer SetParseErrorHook(nil)
+
+	parser, err := NewResponseParser(mockQualitySurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	if err := parser.AddResponse(mockQualityResponse("p1", 1000, "1", []string{"1"}, "30")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := parser.AddResponse(mockQualityResponse("p2", 5, "", []string{"1"}, "30")); err == nil {
+		t.Error("response without version should fail with error")
+	}
+	_ = parser.AddResponse(mockQualityResponse("p3", 1000, "1", []string{"1", "2"}, "30"))
+
+	if counts[PARSE_ERROR_VERSION_NOT_FOUND] != 1 || counts[PARSE_ERROR_UNEXPECTED_RESPONSE_GROUP] != 1 {
+		t.Errorf("unexpected parse error counts: %v", counts)
+	}
+}
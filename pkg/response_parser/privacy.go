@@ -0,0 +1,50 @@
+package response_parser
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// SetPseudonymisation replaces the participant IDs by a keyed hash, exports with different keys can not be
+// linked. It has to be set before adding responses, with an empty key the IDs are kept.
+func (rp *ResponseParser) SetPseudonymisation(key string) {
+	rp.pseudonymisationKey = key
+}
+
+// SetExcludeFreeText removes the columns of text inputs and open text fields. It has to be set before adding
+// responses, so case definitions and derived columns can not use these values either.
+func (rp *ResponseParser) SetExcludeFreeText(exclude bool) {
+	rp.freeTextColumns = nil
+	if !exclude {
+		return
+	}
+	rp.freeTextColumns = map[string]bool{}
+	for _, sv := range rp.surveyVersions {
+		for _, q := range sv.Questions {
+			for _, k := range getFreeTextColumns(q, rp.questionOptionKeySep) {
+				rp.freeTextColumns[k] = true
+			}
+		}
+	}
+}
+
+func (rp ResponseParser) pseudonymise(participantID string) string {
+	if rp.pseudonymisationKey == "" {
+		return participantID
+	}
+	mac := hmac.New(sha256.New, []byte(rp.pseudonymisationKey))
+	mac.Write([]byte(participantID))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// getFreeTextColumns lists the response columns of a question that contain text entered by the participant
+func getFreeTextColumns(question SurveyQuestion, sep string) []string {
+	cols := getInputColumns(question, sep, QUESTION_TYPE_TEXT_INPUT, OPTION_TYPE_TEXT_INPUT)
+	for _, slot := range question.Responses {
+		if slot.ResponseType == QUESTION_TYPE_MATRIX_INPUT {
+			cols = append(cols, question.ID+sep+slot.ID)
+		}
+	}
+	return cols
+}
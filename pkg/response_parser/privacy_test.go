@@ -0,0 +1,149 @@
+package response_parser
+
+import (
+	"strings"
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetFreeTextColumns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		question SurveyQuestion
+		expected []string
+	}{
+		{
+			name: "text input",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_TEXT_INPUT, Responses: []ResponseDef{
+				{ID: "input", ResponseType: QUESTION_TYPE_TEXT_INPUT},
+			}},
+			expected: []string{"Q1"},
+		},
+		{
+			name: "multiple choice with text option",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_MULTIPLE_CHOICE, Responses: []ResponseDef{
+				{ID: "mcg", ResponseType: QUESTION_TYPE_MULTIPLE_CHOICE, Options: []ResponseOption{
+					{ID: "1", OptionType: OPTION_TYPE_CHECKBOX},
+					{ID: "other", OptionType: OPTION_TYPE_TEXT_INPUT},
+				}},
+			}},
+			expected: []string{"Q1-other-open"},
+		},
+		{
+			name: "matrix with input cells",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_MATRIX, Responses: []ResponseDef{
+				{ID: "row1.col1", ResponseType: QUESTION_TYPE_MATRIX_INPUT},
+				{ID: "row1.col2", ResponseType: QUESTION_TYPE_MATRIX_NUMBER_INPUT},
+			}},
+			expected: []string{"Q1-row1.col1"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cols := getFreeTextColumns(tc.question, "-")
+			if strings.Join(cols, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("unexpected columns: %v", cols)
+			}
+		})
+	}
+}
+
+func TestPrivacyRestrictions(t *testing.T) {
+	survey := mockWeeklyILISurvey("en")
+	survey.Current.SurveyDefinition.Items[0] = mockQuestion("weekly.Q1", "en", "Symptoms", mockMultipleChoiceGroup("en", []MockOpionDef{
+		{Key: "fever", Role: "option", Label: "Fever"},
+		{Key: "other", Role: "input", Label: "Other"},
+	}))
+
+	resp := mockWeeklyILIResponse("p1", 100, []string{"fever"}, "yes")
+	resp.Responses[0].Response.Items[0].Items = append(resp.Responses[0].Response.Items[0].Items,
+		&studyAPI.ResponseItem{Key: "other", Value: "my neighbour's cat"})
+
+	t.Run("without restrictions", func(t *testing.T) {
+		parser, err := NewResponseParser(survey, "en", true, "-", nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err.Error())
+			return
+		}
+		if err := parser.AddResponse(mockWeeklyILIResponse("p1", 100, []string{"fever"}, "yes")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		r := parser.GetResponses()[0]
+		if r.ParticipantID != "p1" {
+			t.Errorf("unexpected participant ID: %s", r.ParticipantID)
+		}
+		if _, ok := r.Responses["Q1-other-open"]; !ok {
+			t.Errorf("missing open field: %v", r.Responses)
+		}
+	})
+
+	t.Run("pseudonymised without free text", func(t *testing.T) {
+		parser, err := NewResponseParser(survey, "en", true, "-", nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err.Error())
+			return
+		}
+		parser.SetPseudonymisation("secret")
+		parser.SetExcludeFreeText(true)
+		if err := parser.AddResponse(resp); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if err := parser.AddResponse(mockWeeklyILIResponse("p1", 200, []string{}, "no")); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+
+		responses := parser.GetResponses()
+		if responses[0].ParticipantID == "p1" || len(responses[0].ParticipantID) != 64 {
+			t.Errorf("unexpected participant ID: %s", responses[0].ParticipantID)
+		}
+		if responses[0].ParticipantID != responses[1].ParticipantID {
+			t.Error("pseudonym should be stable")
+		}
+		if _, ok := responses[0].Responses["Q1-other-open"]; ok {
+			t.Errorf("free text should be removed: %v", responses[0].Responses)
+		}
+		if responses[0].Responses["Q1-other"] != TRUE_VALUE {
+			t.Errorf("selection should be kept: %v", responses[0].Responses)
+		}
+
+		other, _ := NewResponseParser(survey, "en", true, "-", nil)
+		other.SetPseudonymisation("other secret")
+		if other.pseudonymise("p1") == responses[0].ParticipantID {
+			t.Error("pseudonyms of different keys should differ")
+		}
+	})
+
+	t.Run("without free text and with column mapping", func(t *testing.T) {
+		mapping := ColumnMapping{VersionID: "1", QuestionKey: "Q1", OptionKey: "other", NewKeys: []string{"fever"}}
+		parser, err := NewResponseParser(survey, "en", true, "-", nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err.Error())
+			return
+		}
+		parser.SetExcludeFreeText(true)
+		if err := parser.SetColumnMappings([]ColumnMapping{mapping}); err == nil {
+			t.Error("mapping of free text should fail with error")
+		}
+
+		// mappings set before the restriction must not rename free text out of the removed columns
+		parser, _ = NewResponseParser(survey, "en", true, "-", nil)
+		if err := parser.SetColumnMappings([]ColumnMapping{mapping}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		parser.SetExcludeFreeText(true)
+		if err := parser.AddResponse(resp); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		for k, v := range parser.GetResponses()[0].Responses {
+			if v == "my neighbour's cat" {
+				t.Errorf("free text exported in %s", k)
+			}
+		}
+	})
+}
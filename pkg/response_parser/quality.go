@@ -0,0 +1,234 @@
+package response_parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+// SetDuplicateWindow configures after how much time a new submission of the same participant is no
+// longer a duplicate. With a zero window only submissions at the same time are duplicates. The window
+// is shared by the data-quality report and the dedup policy.
+func (rp *ResponseParser) SetDuplicateWindow(window time.Duration) {
+	rp.duplicateWindow = window
+}
+
+func (rp *ResponseParser) addQualityIssue(rawResp *studyAPI.SurveyResponse, issueType string, questionKey string, details string) {
+	rp.qualityIssues = append(rp.qualityIssues, DataQualityIssue{
+		Type:          issueType,
+		ParticipantID: rawResp.ParticipantId,
+		Version:       rawResp.VersionId,
+		SubmittedAt:   rawResp.SubmittedAt,
+		QuestionKey:   questionKey,
+		Details:       details,
+	})
+}
+
+// duplicateSubmissionIssues reports the responses that a dedup policy treats as duplicates. They can only
+// be found once all responses are added, because the responses do not arrive in submission order.
+func (rp ResponseParser) duplicateSubmissionIssues() []DataQualityIssue {
+	window := int64(rp.duplicateWindow / time.Second)
+	issues := []DataQualityIssue{}
+	previous := map[string]int64{}
+	for _, r := range sortedBySubmission(rp.responses) {
+		last, ok := previous[r.ParticipantID]
+		previous[r.ParticipantID] = r.SubmittedAt
+		if !ok || !isRepeatedSubmission(last, r.SubmittedAt, window) {
+			continue
+		}
+		issues = append(issues, DataQualityIssue{
+			Type:          QUALITY_ISSUE_DUPLICATE,
+			ParticipantID: r.ParticipantID,
+			Version:       r.Version,
+			SubmittedAt:   r.SubmittedAt,
+			Details:       fmt.Sprintf("submitted %d seconds after the previous response at %s", r.SubmittedAt-last, rp.formatTimestamp(last)),
+		})
+	}
+	return issues
+}
+
+// isReportedIssue checks that the question of an issue only has exported columns, the details of an issue
+// can contain the values of its question. Free text columns count as removed. Items that are not part of
+// the survey version cannot be matched against the filter, they are only reported without column
+// restrictions.
+func (rp ResponseParser) isReportedIssue(issue DataQualityIssue) bool {
+	if issue.QuestionKey == "" {
+		return true
+	}
+	restricted := len(rp.includeColumns) > 0 || len(rp.excludeColumns) > 0 || len(rp.freeTextColumns) > 0
+	version, ok := findVersionPreview(issue.Version, rp.surveyVersions)
+	if !ok {
+		return !restricted
+	}
+	question, ok := findQuestion(issue.QuestionKey, version)
+	if !ok {
+		return !restricted
+	}
+	for col := range getResponseColumns(question, nil, rp.questionOptionKeySep, rp.logger) {
+		if !rp.isExported(col) || rp.freeTextColumns[col] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkResponseKeys reports responses for items that are not part of the matched survey version
+func (rp *ResponseParser) checkResponseKeys(rawResp *studyAPI.SurveyResponse, version SurveyVersionPreview) {
+	for _, r := range rawResp.Responses {
+		known := false
+		for _, q := range version.Questions {
+			if q.ID == r.Key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			rp.addQualityIssue(rawResp, QUALITY_ISSUE_UNKNOWN_KEY, r.Key, fmt.Sprintf("item not found in version %s", version.VersionID))
+		}
+	}
+}
+
+// checkQuestionResponse reports responses to hidden questions, selections that do not fit the response
+// slot definitions and numeric values outside of the defined range
+func (rp *ResponseParser) checkQuestionResponse(rawResp *studyAPI.SurveyResponse, question SurveyQuestion, response *studyAPI.SurveyItemResponse) {
+	if response == nil {
+		return
+	}
+	if response.Response != nil && len(question.conditions) > 0 {
+		shown, ok := evalConditions(question.conditions, func(key string) *studyAPI.SurveyItemResponse {
+			if r := findResponse(rawResp.Responses, key); r != nil {
+				return r
+			}
+			return findResponse(rawResp.Responses, strings.TrimPrefix(key, rp.surveyKey+"."))
+		})
+		if ok && !shown {
+			rp.addQualityIssue(rawResp, QUALITY_ISSUE_HIDDEN_ANSWERED, question.ID, "response while not "+question.Condition)
+		}
+	}
+	for _, rSlot := range question.Responses {
+		rItem := retrieveResponseItem(response, RESPONSE_ROOT_KEY+"."+rSlot.ID)
+		if rItem == nil {
+			continue
+		}
+
+		switch rSlot.ResponseType {
+		case QUESTION_TYPE_SINGLE_CHOICE, QUESTION_TYPE_DROPDOWN, QUESTION_TYPE_LIKERT,
+			QUESTION_TYPE_MATRIX_RADIO_ROW, QUESTION_TYPE_MATRIX_DROPDOWN:
+			if len(rItem.Items) != 1 {
+				rp.addQualityIssue(rawResp, QUALITY_ISSUE_UNEXPECTED_SELECTION, question.ID,
+					fmt.Sprintf("%s: expected 1 selection, found %d", rSlot.ID, len(rItem.Items)))
+			}
+			rp.checkSelectedOptions(rawResp, question.ID, rSlot, rItem.Items)
+		case QUESTION_TYPE_MULTIPLE_CHOICE:
+			rp.checkSelectedOptions(rawResp, question.ID, rSlot, rItem.Items)
+		}
+
+		if (rSlot.Min != nil || rSlot.Max != nil) && rItem.Value != "" {
+			v, err := strconv.ParseFloat(rItem.Value, 64)
+			if err != nil {
+				rp.addQualityIssue(rawResp, QUALITY_ISSUE_OUT_OF_RANGE, question.ID,
+					fmt.Sprintf("%s: value %s is not a number", rSlot.ID, rItem.Value))
+			} else if (rSlot.Min != nil && v < *rSlot.Min) || (rSlot.Max != nil && v > *rSlot.Max) {
+				rp.addQualityIssue(rawResp, QUALITY_ISSUE_OUT_OF_RANGE, question.ID,
+					fmt.Sprintf("%s: value %s outside of %s", rSlot.ID, rItem.Value, formatRange(rSlot.Min, rSlot.Max)))
+			}
+		}
+	}
+}
+
+func (rp *ResponseParser) checkSelectedOptions(rawResp *studyAPI.SurveyResponse, questionKey string, rSlot ResponseDef, selections []*studyAPI.ResponseItem) {
+	for _, item := range selections {
+		known := false
+		for _, o := range rSlot.Options {
+			if o.ID == item.Key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			rp.addQualityIssue(rawResp, QUALITY_ISSUE_UNKNOWN_KEY, questionKey, fmt.Sprintf("%s: unknown option %s", rSlot.ID, item.Key))
+		}
+	}
+}
+
+func formatRange(min *float64, max *float64) string {
+	lower := "-inf"
+	if min != nil {
+		lower = strconv.FormatFloat(*min, 'f', -1, 64)
+	}
+	upper := "inf"
+	if max != nil {
+		upper = strconv.FormatFloat(*max, 'f', -1, 64)
+	}
+	return "[" + lower + ", " + upper + "]"
+}
+
+// GetDataQualityReport lists the duplicate submissions ordered by submission time, followed by the issues
+// found while adding the responses, in the order they were found. Issues of questions with columns removed
+// from the export are left out.
+func (rp ResponseParser) GetDataQualityReport() DataQualityReport {
+	report := DataQualityReport{
+		SurveyKey:     rp.surveyKey,
+		ResponseCount: len(rp.responses),
+		IssueCounts:   map[string]int{},
+		Issues:        rp.duplicateSubmissionIssues(),
+	}
+	for _, issue := range rp.qualityIssues {
+		if rp.isReportedIssue(issue) {
+			report.Issues = append(report.Issues, issue)
+		}
+	}
+	for i, issue := range report.Issues {
+		report.Issues[i].Submitted = rp.formatTimestamp(issue.SubmittedAt)
+	}
+	for _, issue := range report.Issues {
+		report.IssueCounts[issue.Type] += 1
+		if issue.Type == QUALITY_ISSUE_UNMAPPED_VERSION {
+			report.ResponseCount += 1
+		}
+	}
+	return report
+}
+
+func (rp ResponseParser) GetDataQualityReportJSON(writer io.Writer) error {
+	enc := json.NewEncoder(writer)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rp.GetDataQualityReport())
+}
+
+func (rp ResponseParser) GetDataQualityReportCSV(writer io.Writer) error {
+	header := []string{
+		"type", "participantID", "version", "submitted", "questionKey", "details",
+	}
+
+	// Init writer
+	w := rp.newCSVWriter(writer)
+
+	// Write header
+	err := w.WriteHeader(header)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range rp.GetDataQualityReport().Issues {
+		line := []string{
+			issue.Type,
+			issue.ParticipantID,
+			issue.Version,
+			issue.Submitted,
+			issue.QuestionKey,
+			issue.Details,
+		}
+		err := w.Write(line)
+		if err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
@@ -0,0 +1,160 @@
+package response_parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func mockQualitySurvey(testLang string) *studyAPI.Survey {
+	ageInput := mockNumberInput(testLang, "Age")
+	ageInput.Items[0].Properties = &studyAPI.ItemComponent_Properties{
+		Min: &studyAPI.ExpressionArg{Data: &studyAPI.ExpressionArg_Num{Num: 0}},
+		Max: &studyAPI.ExpressionArg{Data: &studyAPI.ExpressionArg_Num{Num: 120}},
+	}
+	return &studyAPI.Survey{
+		Id: "surveyIDfromDB",
+		Current: &studyAPI.SurveyVersion{
+			Published:   10,
+			Unpublished: 0,
+			VersionId:   "1",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key: "weekly",
+				Items: []*studyAPI.SurveyItem{
+					mockQuestion("weekly.Q1", testLang, "Title of Q1", mockSingleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "1", Role: "option", Label: "Yes"},
+						{Key: "2", Role: "option", Label: "No"},
+					})),
+					mockQuestion("weekly.Q2", testLang, "Title of Q2", ageInput),
+				},
+			},
+		},
+	}
+}
+
+func mockQualityResponse(pID string, submittedAt int64, versionID string, q1 []string, age string) *studyAPI.SurveyResponse {
+	selections := []*studyAPI.ResponseItem{}
+	for _, k := range q1 {
+		selections = append(selections, &studyAPI.ResponseItem{Key: k})
+	}
+	return &studyAPI.SurveyResponse{Key: "weekly", ParticipantId: pID, SubmittedAt: submittedAt, VersionId: versionID,
+		Responses: []*studyAPI.SurveyItemResponse{
+			{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "scg", Items: selections},
+			}}},
+			{Key: "weekly.Q2", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+				{Key: "num", Value: age},
+			}}},
+		},
+	}
+}
+
+func TestDataQualityReport(t *testing.T) {
+	parser, err := NewResponseParser(mockQualitySurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	parser.SetDuplicateWindow(10 * time.Minute)
+
+	withUnknownItem := mockQualityResponse("p4", 5000, "1", []string{"1"}, "40")
+	withUnknownItem.Responses = append(withUnknownItem.Responses, &studyAPI.SurveyItemResponse{Key: "weekly.Q9"})
+
+	for _, r := range []*studyAPI.SurveyResponse{
+		mockQualityResponse("p1", 1000, "1", []string{"1"}, "30"),
+		mockQualityResponse("p1", 1300, "1", []string{"1"}, "30"),
+		mockQualityResponse("p1", 3000, "1", []string{"2"}, "30"),
+		mockQualityResponse("p2", 1000, "1", []string{"1", "2"}, "150"),
+		mockQualityResponse("p3", 5, "", []string{"3"}, "abc"),
+		mockQualityResponse("p3", 1000, "1", []string{"3"}, "abc"),
+		withUnknownItem,
+	} {
+		_ = parser.AddResponse(r)
+	}
+
+	report := parser.GetDataQualityReport()
+	if report.ResponseCount != 7 {
+		t.Errorf("unexpected response count: %d", report.ResponseCount)
+	}
+	expectedCounts := map[string]int{
+		QUALITY_ISSUE_DUPLICATE:            1,
+		QUALITY_ISSUE_UNMAPPED_VERSION:     1,
+		QUALITY_ISSUE_UNEXPECTED_SELECTION: 1,
+		QUALITY_ISSUE_OUT_OF_RANGE:         2,
+		QUALITY_ISSUE_UNKNOWN_KEY:          2,
+	}
+	for k, v := range expectedCounts {
+		if report.IssueCounts[k] != v {
+			t.Errorf("unexpected count for %s: %d", k, report.IssueCounts[k])
+		}
+	}
+	if report.Issues[0].Type != QUALITY_ISSUE_DUPLICATE || report.Issues[0].SubmittedAt != 1300 {
+		t.Errorf("unexpected first issue: %v", report.Issues[0])
+	}
+
+	t.Run("json", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parser.GetDataQualityReportJSON(buf); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		decoded := DataQualityReport{}
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(decoded.Issues) != len(report.Issues) {
+			t.Errorf("unexpected number of issues: %d", len(decoded.Issues))
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parser.GetDataQualityReportCSV(buf); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != len(report.Issues)+1 {
+			t.Errorf("unexpected number of lines: %d", len(lines))
+		}
+		if !strings.Contains(buf.String(), "num: value 150 outside of [0, 120]") {
+			t.Errorf("range issue missing: %s", buf.String())
+		}
+	})
+}
+
+func TestRestrictedDataQualityReport(t *testing.T) {
+	parser, err := NewResponseParser(mockQualitySurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	parser.SetPseudonymisation("secret")
+	if err := parser.SetColumnFilter(nil, []string{"Q2*"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	withUnknownItem := mockQualityResponse("p4", 5000, "1", []string{"1"}, "40")
+	withUnknownItem.Responses = append(withUnknownItem.Responses, &studyAPI.SurveyItemResponse{Key: "weekly.Q9"})
+	for _, r := range []*studyAPI.SurveyResponse{
+		mockQualityResponse("p2", 1000, "1", []string{"1", "2"}, "150"),
+		withUnknownItem,
+	} {
+		_ = parser.AddResponse(r)
+	}
+
+	report := parser.GetDataQualityReport()
+	if len(report.Issues) != 1 || report.Issues[0].Type != QUALITY_ISSUE_UNEXPECTED_SELECTION {
+		t.Errorf("unexpected issues: %v", report.Issues)
+		return
+	}
+	if report.Issues[0].ParticipantID != parser.pseudonymise("p2") {
+		t.Errorf("unexpected participant ID: %s", report.Issues[0].ParticipantID)
+	}
+}
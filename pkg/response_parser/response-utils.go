@@ -3,11 +3,11 @@ package response_parser
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 )
 
 func findSurveyVersion(versionID string, submittedAt int64, versions []SurveyVersionPreview) (sv SurveyVersionPreview, err error) {
@@ -67,14 +67,14 @@ func findResponse(responses []*studyAPI.SurveyItemResponse, key string) *studyAP
 	return nil
 }
 
-func getResponseColumns(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func getResponseColumns(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	switch question.QuestionType {
 	case QUESTION_TYPE_SINGLE_CHOICE:
-		return processResponseForSingleChoice(question, response, questionOptionSep)
+		return processResponseForSingleChoice(question, response, questionOptionSep, logger)
 	case QUESTION_TYPE_DROPDOWN:
-		return processResponseForSingleChoice(question, response, questionOptionSep)
+		return processResponseForSingleChoice(question, response, questionOptionSep, logger)
 	case QUESTION_TYPE_LIKERT:
-		return processResponseForSingleChoice(question, response, questionOptionSep)
+		return processResponseForSingleChoice(question, response, questionOptionSep, logger)
 	case QUESTION_TYPE_MULTIPLE_CHOICE:
 		return processResponseForMultipleChoice(question, response, questionOptionSep)
 	case QUESTION_TYPE_TEXT_INPUT:
@@ -88,28 +88,28 @@ func getResponseColumns(question SurveyQuestion, response *studyAPI.SurveyItemRe
 	case QUESTION_TYPE_EQ5D_SLIDER:
 		return processResponseForInputs(question, response, questionOptionSep)
 	case QUESTION_TYPE_MATRIX:
-		return processResponseForMatrix(question, response, questionOptionSep)
+		return processResponseForMatrix(question, response, questionOptionSep, logger)
 	case QUESTION_TYPE_UNKNOWN:
-		return processResponseForUnknown(question, response, questionOptionSep)
+		return processResponseForUnknown(question, response, questionOptionSep, logger)
 	default:
 		return map[string]string{}
 	}
 }
 
-func processResponseForSingleChoice(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func processResponseForSingleChoice(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	var responseCols map[string]string
 
 	if len(question.Responses) == 1 {
 		rSlot := question.Responses[0]
-		responseCols = handleSimpleSingleChoiceGroup(question.ID, rSlot, response, questionOptionSep)
+		responseCols = handleSimpleSingleChoiceGroup(question.ID, rSlot, response, questionOptionSep, logger)
 
 	} else {
-		responseCols = handleSingleChoiceGroupList(question.ID, question.Responses, response, questionOptionSep)
+		responseCols = handleSingleChoiceGroupList(question.ID, question.Responses, response, questionOptionSep, logger)
 	}
 	return responseCols
 }
 
-func handleSimpleSingleChoiceGroup(questionKey string, responseSlotDef ResponseDef, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func handleSimpleSingleChoiceGroup(questionKey string, responseSlotDef ResponseDef, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	responseCols := map[string]string{}
 
 	// Prepare columns:
@@ -126,7 +126,7 @@ func handleSimpleSingleChoiceGroup(questionKey string, responseSlotDef ResponseD
 	rGroup := retrieveResponseItem(response, RESPONSE_ROOT_KEY+"."+responseSlotDef.ID)
 	if rGroup != nil {
 		if len(rGroup.Items) != 1 {
-			log.Printf("unexpected response group for question %s: %v", questionKey, rGroup)
+			logUnexpectedResponseGroup(logger, questionKey, rGroup)
 		} else {
 			selection := rGroup.Items[0]
 			responseCols[questionKey] = selection.Key
@@ -140,7 +140,7 @@ func handleSimpleSingleChoiceGroup(questionKey string, responseSlotDef ResponseD
 	return responseCols
 }
 
-func handleSingleChoiceGroupList(questionKey string, responseSlotDefs []ResponseDef, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func handleSingleChoiceGroupList(questionKey string, responseSlotDefs []ResponseDef, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	responseCols := map[string]string{}
 
 	// Prepare columns:
@@ -160,7 +160,7 @@ func handleSingleChoiceGroupList(questionKey string, responseSlotDefs []Response
 		if rGroup == nil {
 			continue
 		} else if len(rGroup.Items) != 1 {
-			log.Printf("unexpected response group for question %s: %v", questionKey, rGroup)
+			logUnexpectedResponseGroup(logger, questionKey, rGroup)
 			continue
 		}
 
@@ -307,7 +307,7 @@ func handleInputList(questionKey string, responseSlotDefs []ResponseDef, respons
 	return responseCols
 }
 
-func processResponseForMatrix(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func processResponseForMatrix(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	responseCols := map[string]string{}
 
 	for _, rSlot := range question.Responses {
@@ -319,7 +319,7 @@ func processResponseForMatrix(question SurveyQuestion, response *studyAPI.Survey
 			responseCols[slotKey] = ""
 			if rGroup != nil {
 				if len(rGroup.Items) != 1 {
-					log.Printf("unexpected response group for question %s: %v", question.ID, rGroup)
+					logUnexpectedResponseGroup(logger, question.ID, rGroup)
 				} else {
 					selection := rGroup.Items[0]
 					responseCols[slotKey] = selection.Key
@@ -330,7 +330,7 @@ func processResponseForMatrix(question SurveyQuestion, response *studyAPI.Survey
 			responseCols[slotKey] = ""
 			if rGroup != nil {
 				if len(rGroup.Items) != 1 {
-					log.Printf("unexpected response group for question %s: %v", question.ID, rGroup)
+					logUnexpectedResponseGroup(logger, question.ID, rGroup)
 				} else {
 					selection := rGroup.Items[0]
 					value := selection.Key
@@ -345,7 +345,7 @@ func processResponseForMatrix(question SurveyQuestion, response *studyAPI.Survey
 	return responseCols
 }
 
-func processResponseForUnknown(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+func processResponseForUnknown(question SurveyQuestion, response *studyAPI.SurveyItemResponse, questionOptionSep string, logger *zap.Logger) map[string]string {
 	responseCols := map[string]string{}
 
 	for _, rSlot := range question.Responses {
@@ -364,7 +364,7 @@ func processResponseForUnknown(question SurveyQuestion, response *studyAPI.Surve
 		if rGroup != nil {
 			if len(rGroup.Items) > 0 {
 				if len(rGroup.Items) > 1 {
-					log.Printf("unexpected response group for question %s: %v", question.ID, rGroup)
+					logUnexpectedResponseGroup(logger, question.ID, rGroup)
 				} else {
 					selection := rGroup.Items[0]
 					responseCols[slotKey] = selection.Key
@@ -415,3 +415,40 @@ func retrieveResponseItem(response *studyAPI.SurveyItemResponse, fullKey string)
 	}
 	return result
 }
+
+// getInputColumns lists the response columns of a question that contain the value of a response slot of the
+// given type or of an option of the given type
+func getInputColumns(question SurveyQuestion, sep string, responseType string, optionType string) []string {
+	cols := []string{}
+	singleSlot := len(question.Responses) == 1 && question.QuestionType != QUESTION_TYPE_UNKNOWN
+	for _, slot := range question.Responses {
+		slotKey := question.ID + sep + slot.ID
+		if slot.ResponseType == responseType {
+			if singleSlot {
+				cols = append(cols, question.ID)
+			} else {
+				cols = append(cols, slotKey)
+			}
+			continue
+		}
+
+		for _, o := range slot.Options {
+			if o.OptionType != optionType {
+				continue
+			}
+			switch {
+			case question.QuestionType == QUESTION_TYPE_UNKNOWN:
+				cols = append(cols, slotKey+"."+o.ID)
+			case slot.ResponseType == QUESTION_TYPE_MULTIPLE_CHOICE && singleSlot:
+				cols = append(cols, question.ID+sep+o.ID+sep+OPEN_FIELD_COL_SUFFIX)
+			case slot.ResponseType == QUESTION_TYPE_MULTIPLE_CHOICE:
+				cols = append(cols, slotKey+"."+o.ID+sep+OPEN_FIELD_COL_SUFFIX)
+			case singleSlot:
+				cols = append(cols, question.ID+sep+o.ID)
+			default:
+				cols = append(cols, slotKey+"."+o.ID)
+			}
+		}
+	}
+	return cols
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 )
 
 func TestFindSurveyVersion(t *testing.T) {
@@ -78,7 +79,7 @@ func TestGetResponseColumns(t *testing.T) {
 			Responses:    []ResponseDef{},
 		}, &studyAPI.SurveyItemResponse{
 			Key: "test",
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) > 0 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -106,7 +107,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -127,7 +128,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{ID: "4", OptionType: OPTION_TYPE_DATE_INPUT},
 				}},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 3 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -163,7 +164,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 6 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -194,7 +195,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 6 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -215,7 +216,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{ID: "4", OptionType: OPTION_TYPE_DATE_INPUT},
 				}},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 6 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -251,7 +252,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 12 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -271,7 +272,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp", Value: "hello"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -289,7 +290,7 @@ func TestGetResponseColumns(t *testing.T) {
 				{ID: "inp1", ResponseType: QUESTION_TYPE_TEXT_INPUT},
 				{ID: "inp2", ResponseType: QUESTION_TYPE_TEXT_INPUT},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -312,7 +313,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp1", Value: "hello"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -336,7 +337,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp", Value: "1327", Dtype: "number"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -354,7 +355,7 @@ func TestGetResponseColumns(t *testing.T) {
 				{ID: "inp1", ResponseType: QUESTION_TYPE_NUMBER_INPUT},
 				{ID: "inp2", ResponseType: QUESTION_TYPE_NUMBER_INPUT},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -375,7 +376,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp", Value: "1327", Dtype: "date"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -393,7 +394,7 @@ func TestGetResponseColumns(t *testing.T) {
 				{ID: "inp1", ResponseType: QUESTION_TYPE_DATE_INPUT},
 				{ID: "inp2", ResponseType: QUESTION_TYPE_DATE_INPUT},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -422,7 +423,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -441,7 +442,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{ID: "2", OptionType: OPTION_TYPE_DROPDOWN_OPTION},
 				}},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -473,7 +474,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -502,7 +503,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -544,7 +545,7 @@ func TestGetResponseColumns(t *testing.T) {
 					},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -569,7 +570,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{ID: "3", OptionType: OPTION_TYPE_RADIO},
 				}},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 		}
@@ -589,7 +590,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp", Value: "1327"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -607,7 +608,7 @@ func TestGetResponseColumns(t *testing.T) {
 				{ID: "inp1", ResponseType: QUESTION_TYPE_EQ5D_SLIDER},
 				{ID: "inp2", ResponseType: QUESTION_TYPE_EQ5D_SLIDER},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -628,7 +629,7 @@ func TestGetResponseColumns(t *testing.T) {
 					{Key: "inp", Value: "1327"},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 1 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -646,7 +647,7 @@ func TestGetResponseColumns(t *testing.T) {
 				{ID: "inp1", ResponseType: QUESTION_TYPE_NUMERIC_SLIDER},
 				{ID: "inp2", ResponseType: QUESTION_TYPE_NUMERIC_SLIDER},
 			},
-		}, nil, questionOptionSep)
+		}, nil, questionOptionSep, zap.NewNop())
 		if len(cols) != 2 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -685,7 +686,7 @@ func TestGetResponseColumns(t *testing.T) {
 					}},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 3 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -716,7 +717,7 @@ func TestGetResponseColumns(t *testing.T) {
 					}},
 				},
 			},
-		}, questionOptionSep)
+		}, questionOptionSep, zap.NewNop())
 		if len(cols) != 3 {
 			t.Errorf("unexpected results: %v", cols)
 			return
@@ -1,15 +1,16 @@
 package response_parser
 
 import (
-	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 )
 
 type ResponseParser struct {
@@ -18,9 +19,33 @@ type ResponseParser struct {
 	responses            []ParsedResponse
 	contextColNames      []string
 	responseColNames     []string
+	derivedColNames      []string
+	timingColNames       []string
 	metaColNames         []string
 	shortQuestionKeys    bool
 	questionOptionKeySep string
+	caseDefinitions      []CaseDefinition
+	derivedColumns       []derivedColumn
+	includeTiming        bool
+	duplicateWindow      time.Duration
+	qualityIssues        []DataQualityIssue
+	dedupPolicy          string
+	missingValueCoding   *MissingValueCoding
+	columnMappings       map[string][]ColumnMapping
+	currentColumns       map[string]bool
+	timestampFormat      TimestampFormat
+	timestampLocation    *time.Location
+	dateColumns          map[string]bool
+	booleanEncoding      BooleanEncoding
+	booleanColumns       map[string]bool
+	arrayEncoding        ArrayEncoding
+	csvDialect           CSVDialect
+	pseudonymisationKey  string
+	freeTextColumns      map[string]bool
+	includeColumns       []string
+	excludeColumns       []string
+	optionLabels         map[string]map[string]string
+	logger               *zap.Logger
 }
 
 func NewResponseParser(
@@ -28,7 +53,11 @@ func NewResponseParser(
 	previewLang string,
 	shortQuestionKeys bool,
 	questionOptionSep string,
+	logger *zap.Logger,
 ) (*ResponseParser, error) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
 	if surveyDef == nil || surveyDef.Current == nil || surveyDef.Current.SurveyDefinition == nil {
 		return nil, errors.New("current survey definition not found")
 	}
@@ -39,17 +68,26 @@ func NewResponseParser(
 		responses:            []ParsedResponse{},
 		shortQuestionKeys:    shortQuestionKeys,
 		questionOptionKeySep: questionOptionSep,
+		timestampFormat:      TimestampFormat{Format: TIME_FORMAT_UNIX, Timezone: "UTC"},
+		timestampLocation:    time.UTC,
+		booleanEncoding:      BooleanEncoding{True: TRUE_VALUE, False: FALSE_VALUE},
+		arrayEncoding:        ArrayEncoding{Separator: ";"},
+		logger:               logger.With(zap.String("survey", surveyDef.Current.SurveyDefinition.Key)),
 	}
 
-	rp.surveyVersions = append(rp.surveyVersions, surveyDefToVersionPreview(surveyDef.Current, previewLang))
+	rp.surveyVersions = append(rp.surveyVersions, surveyDefToVersionPreview(surveyDef.Current, previewLang, rp.logger))
 	for _, v := range surveyDef.History {
-		rp.surveyVersions = append(rp.surveyVersions, surveyDefToVersionPreview(v, previewLang))
+		rp.surveyVersions = append(rp.surveyVersions, surveyDefToVersionPreview(v, previewLang, rp.logger))
 	}
 
 	for versionInd, sv := range rp.surveyVersions {
 		for qInd, question := range sv.Questions {
 			if shortQuestionKeys {
 				rp.surveyVersions[versionInd].Questions[qInd].ID = strings.TrimPrefix(question.ID, rp.surveyKey+".")
+				rp.surveyVersions[versionInd].Questions[qInd].Condition = strings.ReplaceAll(question.Condition, rp.surveyKey+".", "")
+				for i, v := range question.Validations {
+					rp.surveyVersions[versionInd].Questions[qInd].Validations[i] = strings.ReplaceAll(v, rp.surveyKey+".", "")
+				}
 			}
 		}
 
@@ -58,12 +96,16 @@ func NewResponseParser(
 }
 
 func (rp *ResponseParser) AddResponse(rawResp *studyAPI.SurveyResponse) error {
+	rawResp.ParticipantId = rp.pseudonymise(rawResp.ParticipantId)
 	parsedResponse := ParsedResponse{
 		ParticipantID: rawResp.ParticipantId,
 		Version:       rawResp.VersionId,
 		SubmittedAt:   rawResp.SubmittedAt,
 		Context:       rawResp.Context,
 		Responses:     map[string]string{},
+		Derived:       map[string]string{},
+		Timing:        map[string]string{},
+		Missing:       map[string]string{},
 		Meta: ResponseMeta{
 			Initialised: map[string]string{},
 			Displayed:   map[string]string{},
@@ -74,6 +116,8 @@ func (rp *ResponseParser) AddResponse(rawResp *studyAPI.SurveyResponse) error {
 
 	currentVersion, err := findSurveyVersion(rawResp.VersionId, rawResp.SubmittedAt, rp.surveyVersions)
 	if err != nil {
+		rp.addQualityIssue(rawResp, QUALITY_ISSUE_UNMAPPED_VERSION, "", err.Error())
+		reportParseError(PARSE_ERROR_VERSION_NOT_FOUND)
 		return err
 	}
 
@@ -82,14 +126,33 @@ func (rp *ResponseParser) AddResponse(rawResp *studyAPI.SurveyResponse) error {
 			rawResp.Responses[i].Key = strings.TrimPrefix(r.Key, rp.surveyKey+".")
 		}
 	}
+	rp.checkResponseKeys(rawResp, currentVersion)
 
 	for _, question := range currentVersion.Questions {
 		resp := findResponse(rawResp.Responses, question.ID)
 
-		responseColumns := getResponseColumns(question, resp, rp.questionOptionKeySep)
+		rp.checkQuestionResponse(rawResp, question, resp)
+
+		responseColumns := getResponseColumns(question, resp, rp.questionOptionKeySep, rp.logger)
 		for k, v := range responseColumns {
 			parsedResponse.Responses[k] = v
 		}
+		if rp.missingValueCoding != nil {
+			missingReason := getMissingReason(resp)
+			// some question types leave out the columns when nothing is selected
+			for k := range getResponseColumns(question, nil, rp.questionOptionKeySep, rp.logger) {
+				if responseColumns[k] == "" {
+					parsedResponse.Missing[k] = missingReason
+				}
+			}
+		}
+
+		if rp.includeTiming {
+			for k, v := range getQuestionTiming(question.ID, resp, rp.questionOptionKeySep) {
+				parsedResponse.Timing[k] = v
+				rp.AddTimingColName(k)
+			}
+		}
 
 		// Set meta infos
 		initColName := question.ID + rp.questionOptionKeySep + "metaInit"
@@ -108,15 +171,42 @@ func (rp *ResponseParser) AddResponse(rawResp *studyAPI.SurveyResponse) error {
 		rp.AddMetaColName(itemVColName)
 		parsedResponse.Meta.ItemVersion[itemVColName] = ""
 
-		arraySep := ";"
 		if resp != nil && resp.Meta != nil {
-			parsedResponse.Meta.Initialised[initColName] = timestampsToStr(resp.Meta.Rendered, arraySep)
-			parsedResponse.Meta.Displayed[dispColName] = timestampsToStr(resp.Meta.Displayed, arraySep)
-			parsedResponse.Meta.Responded[respColName] = timestampsToStr(resp.Meta.Responded, arraySep)
+			parsedResponse.Meta.Initialised[initColName] = rp.formatTimestamps(resp.Meta.Rendered)
+			parsedResponse.Meta.Displayed[dispColName] = rp.formatTimestamps(resp.Meta.Displayed)
+			parsedResponse.Meta.Responded[respColName] = rp.formatTimestamps(resp.Meta.Responded)
 			parsedResponse.Meta.ItemVersion[itemVColName] = strconv.Itoa(int(resp.Meta.Version))
 		}
 	}
 
+	// free text is removed under the names of the response's version, before mappings can rename the columns
+	for k := range rp.freeTextColumns {
+		delete(parsedResponse.Responses, k)
+		delete(parsedResponse.Missing, k)
+	}
+	if len(rp.columnMappings[currentVersion.VersionID]) > 0 {
+		parsedResponse.Responses = rp.harmoniseColumns(currentVersion, parsedResponse.Responses, true)
+		parsedResponse.Missing = rp.harmoniseColumns(currentVersion, parsedResponse.Missing, false)
+	}
+
+	if rp.includeTiming {
+		parsedResponse.Timing[TIMING_COL_DURATION] = getSurveyDuration(rawResp.Responses)
+		rp.AddTimingColName(TIMING_COL_DURATION)
+	}
+
+	for _, cd := range rp.caseDefinitions {
+		v := FALSE_VALUE
+		if evalCaseRule(cd.Rule, parsedResponse.Responses) {
+			v = TRUE_VALUE
+		}
+		parsedResponse.Derived[cd.Name] = v
+		rp.AddDerivedColName(cd.Name)
+	}
+	for _, dc := range rp.derivedColumns {
+		parsedResponse.Derived[dc.name] = dc.expr.evaluate(exprEnv{lookup: parsedResponse.lookupColumn, location: rp.timestampLocation})
+		rp.AddDerivedColName(dc.name)
+	}
+
 	// Extend response col names:
 	for k := range parsedResponse.Responses {
 		rp.AddResponseColName(k)
@@ -147,6 +237,15 @@ func (rp *ResponseParser) AddContextColName(name string) {
 	rp.contextColNames = append(rp.contextColNames, name)
 }
 
+func (rp *ResponseParser) AddDerivedColName(name string) {
+	for _, n := range rp.derivedColNames {
+		if n == name {
+			return
+		}
+	}
+	rp.derivedColNames = append(rp.derivedColNames, name)
+}
+
 func (rp *ResponseParser) AddMetaColName(name string) {
 	for _, n := range rp.metaColNames {
 		if n == name {
@@ -161,49 +260,71 @@ func (rp ResponseParser) GetSurveyVersionDefs() []SurveyVersionPreview {
 }
 
 func (rp ResponseParser) GetResponses() []ParsedResponse {
-	return rp.responses
+	return rp.dedupedResponses()
 }
 
 func (rp ResponseParser) GetResponsesCSV(writer io.Writer, includeMeta bool) error {
-	if len(rp.responses) < 1 {
+	responses := rp.dedupedResponses()
+	if len(responses) < 1 {
 		return errors.New("no responses, nothing is generated")
 	}
 
 	// Sort column names
 	contextCols := rp.contextColNames
 	sort.Strings(contextCols)
+	contextCols = rp.filterColumns(contextCols)
 	responseCols := rp.responseColNames
 	sort.Strings(responseCols)
+	responseCols = rp.filterColumns(responseCols)
+	derivedCols := rp.filterColumns(rp.derivedColNames)
+	timingCols := rp.timingColNames
+	sort.Strings(timingCols)
+	timingCols = rp.filterColumns(timingCols)
 	metaCols := rp.metaColNames
 	sort.Strings(metaCols)
+	metaCols = rp.filterColumns(metaCols)
 
 	// Prepare csv header
+	submittedHeader, _ := rp.submittedCols(0)
 	header := []string{
 		"participantID",
 		"version",
-		"submitted",
+	}
+	header = append(header, submittedHeader...)
+	if rp.dedupPolicy == DEDUP_POLICY_KEEP_ALL {
+		header = append(header, "duplicate_of")
 	}
 	header = append(header, contextCols...)
 	header = append(header, responseCols...)
+	header = append(header, derivedCols...)
+	header = append(header, timingCols...)
 	if includeMeta {
 		header = append(header, metaCols...)
 	}
 
 	// Init writer
-	w := csv.NewWriter(writer)
+	w := rp.newCSVWriter(writer)
 
 	// Write header
-	err := w.Write(header)
+	err := w.WriteHeader(header)
 	if err != nil {
 		return err
 	}
 
 	// Write responses
-	for _, resp := range rp.responses {
+	for _, resp := range responses {
+		_, submitted := rp.submittedCols(resp.SubmittedAt)
 		line := []string{
 			resp.ParticipantID,
 			resp.Version,
-			fmt.Sprint(resp.SubmittedAt),
+		}
+		line = append(line, submitted...)
+		if rp.dedupPolicy == DEDUP_POLICY_KEEP_ALL {
+			duplicateOf := ""
+			if resp.DuplicateOf > 0 {
+				duplicateOf = rp.formatTimestamp(resp.DuplicateOf)
+			}
+			line = append(line, duplicateOf)
 		}
 
 		for _, colName := range contextCols {
@@ -216,12 +337,15 @@ func (rp ResponseParser) GetResponsesCSV(writer io.Writer, includeMeta bool) err
 		}
 
 		for _, colName := range responseCols {
-			v, ok := resp.Responses[colName]
-			if !ok {
-				line = append(line, "")
-				continue
-			}
-			line = append(line, v)
+			line = append(line, rp.exportValue(resp, colName))
+		}
+
+		for _, colName := range derivedCols {
+			line = append(line, rp.encodeBoolean(resp.Derived[colName]))
+		}
+
+		for _, colName := range timingCols {
+			line = append(line, rp.encodeBoolean(resp.Timing[colName]))
 		}
 
 		if includeMeta {
@@ -264,20 +388,21 @@ func (rp ResponseParser) GetResponsesCSV(writer io.Writer, includeMeta bool) err
 		}
 	}
 	w.Flush()
-	return nil
+	return w.Error()
 }
 
 func (rp ResponseParser) GetSurveyInfoCSV(writer io.Writer) error {
 	header := []string{
 		"surveyKey", "versionID", "questionKey", "title",
 		"responseKey", "type", "optionKey", "optionType", "optionLabel",
+		"condition", "validations",
 	}
 
 	// Init writer
-	w := csv.NewWriter(writer)
+	w := rp.newCSVWriter(writer)
 
 	// Write header
-	err := w.Write(header)
+	err := w.WriteHeader(header)
 	if err != nil {
 		return err
 	}
@@ -295,6 +420,10 @@ func (rp ResponseParser) GetSurveyInfoCSV(writer io.Writer) error {
 				question.ID,
 				question.Title,
 			}
+			logicCols := []string{
+				question.Condition,
+				strings.Join(question.Validations, "; "),
+			}
 			for _, slot := range question.Responses {
 				slotCols := []string{
 					slot.ID,
@@ -311,6 +440,7 @@ func (rp ResponseParser) GetSurveyInfoCSV(writer io.Writer) error {
 							option.OptionType,
 							option.Label,
 						}...)
+						line = append(line, logicCols...)
 
 						err := w.Write(line)
 						if err != nil {
@@ -326,6 +456,7 @@ func (rp ResponseParser) GetSurveyInfoCSV(writer io.Writer) error {
 						"",
 						"",
 					}...)
+					line = append(line, logicCols...)
 					err := w.Write(line)
 					if err != nil {
 						return err
@@ -337,5 +468,5 @@ func (rp ResponseParser) GetSurveyInfoCSV(writer io.Writer) error {
 	}
 
 	w.Flush()
-	return nil
+	return w.Error()
 }
@@ -44,7 +44,7 @@ func TestResponseParser(t *testing.T) {
 	}
 
 	t.Run("with with missing surveyDef", func(t *testing.T) {
-		_, err := NewResponseParser(nil, "en", true, questionOptionSep)
+		_, err := NewResponseParser(nil, "en", true, questionOptionSep, nil)
 		if err == nil {
 			t.Error("error expected")
 			return
@@ -62,7 +62,7 @@ func TestResponseParser(t *testing.T) {
 			History: []*studyAPI.SurveyVersion{},
 		}
 
-		_, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep)
+		_, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep, nil)
 		if err == nil {
 			t.Error("error expected")
 			return
@@ -83,7 +83,7 @@ func TestResponseParser(t *testing.T) {
 			},
 		}
 
-		rp, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep)
+		rp, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep, nil)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -123,7 +123,7 @@ func TestResponseParser(t *testing.T) {
 			},
 		}
 
-		rp, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep)
+		rp, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep, nil)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 			return
@@ -169,7 +169,7 @@ func TestGetResponseCSV(t *testing.T) {
 			SurveyDefinition: testSurveyDef,
 		},
 	}
-	parser, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep)
+	parser, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep, nil)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err.Error())
 		return
@@ -0,0 +1,253 @@
+package response_parser
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+var DefaultQuantiles = []float64{0.25, 0.5, 0.75}
+
+const (
+	statSlotCategorical = iota
+	statSlotMultipleChoice
+	statSlotNumeric
+)
+
+type statisticSlot struct {
+	key        string
+	slotType   int
+	optionKeys []string
+	// column name for each option (only used for multiple choice)
+	optionCols map[string]string
+}
+
+// GetResponseStatistics computes per question and survey version counts and numeric summaries
+// from the already parsed response columns, so that the results match the exported values.
+func (rp ResponseParser) GetResponseStatistics(quantiles []float64) []QuestionStatistics {
+	return rp.computeStatistics(rp.responses, nil, quantiles)
+}
+
+func (rp ResponseParser) computeStatistics(responses []ParsedResponse, questionKeys []string, quantiles []float64) []QuestionStatistics {
+	if len(quantiles) == 0 {
+		quantiles = DefaultQuantiles
+	}
+
+	responsesByVersion := make([][]ParsedResponse, len(rp.surveyVersions))
+	for _, resp := range responses {
+		vInd := rp.findSurveyVersionIndex(resp.Version, resp.SubmittedAt)
+		if vInd < 0 {
+			continue
+		}
+		responsesByVersion[vInd] = append(responsesByVersion[vInd], resp)
+	}
+
+	stats := []QuestionStatistics{}
+	for vInd, sv := range rp.surveyVersions {
+		versionResponses := responsesByVersion[vInd]
+		for _, question := range sv.Questions {
+			if len(questionKeys) > 0 && !containsKey(questionKeys, question.ID) {
+				continue
+			}
+			slots := getStatisticSlots(question, rp.questionOptionKeySep)
+			if len(slots) < 1 {
+				continue
+			}
+
+			qStat := QuestionStatistics{
+				VersionID:     sv.VersionID,
+				QuestionKey:   question.ID,
+				QuestionType:  question.QuestionType,
+				ResponseCount: int64(len(versionResponses)),
+				Slots:         make([]ResponseSlotStatistics, len(slots)),
+			}
+			for i, slot := range slots {
+				qStat.Slots[i] = computeSlotStatistics(slot, versionResponses, quantiles)
+			}
+			stats = append(stats, qStat)
+		}
+	}
+	return stats
+}
+
+func (rp ResponseParser) findSurveyVersionIndex(versionID string, submittedAt int64) int {
+	sv, err := findSurveyVersion(versionID, submittedAt, rp.surveyVersions)
+	if err != nil {
+		reportParseError(PARSE_ERROR_VERSION_NOT_FOUND)
+		return -1
+	}
+	for i, v := range rp.surveyVersions {
+		if v.VersionID == sv.VersionID && v.Published == sv.Published {
+			return i
+		}
+	}
+	return -1
+}
+
+func getStatisticSlots(question SurveyQuestion, questionOptionSep string) []statisticSlot {
+	slots := []statisticSlot{}
+
+	slotKey := func(rSlot ResponseDef) string {
+		if len(question.Responses) == 1 {
+			return question.ID
+		}
+		return question.ID + questionOptionSep + rSlot.ID
+	}
+
+	switch question.QuestionType {
+	case QUESTION_TYPE_SINGLE_CHOICE, QUESTION_TYPE_DROPDOWN, QUESTION_TYPE_LIKERT:
+		for _, rSlot := range question.Responses {
+			slot := statisticSlot{
+				key:      slotKey(rSlot),
+				slotType: statSlotCategorical,
+			}
+			for _, option := range rSlot.Options {
+				slot.optionKeys = append(slot.optionKeys, option.ID)
+			}
+			slots = append(slots, slot)
+		}
+	case QUESTION_TYPE_MULTIPLE_CHOICE:
+		for _, rSlot := range question.Responses {
+			slot := statisticSlot{
+				key:        slotKey(rSlot),
+				slotType:   statSlotMultipleChoice,
+				optionCols: map[string]string{},
+			}
+			optionPrefix := question.ID + questionOptionSep
+			if len(question.Responses) > 1 {
+				optionPrefix = question.ID + questionOptionSep + rSlot.ID + "."
+			}
+			for _, option := range rSlot.Options {
+				slot.optionKeys = append(slot.optionKeys, option.ID)
+				slot.optionCols[option.ID] = optionPrefix + option.ID
+			}
+			slots = append(slots, slot)
+		}
+	case QUESTION_TYPE_NUMBER_INPUT, QUESTION_TYPE_NUMERIC_SLIDER, QUESTION_TYPE_EQ5D_SLIDER:
+		for _, rSlot := range question.Responses {
+			slots = append(slots, statisticSlot{
+				key:      slotKey(rSlot),
+				slotType: statSlotNumeric,
+			})
+		}
+	}
+	return slots
+}
+
+func computeSlotStatistics(slot statisticSlot, responses []ParsedResponse, quantiles []float64) ResponseSlotStatistics {
+	stat := ResponseSlotStatistics{
+		Key: slot.key,
+	}
+
+	switch slot.slotType {
+	case statSlotCategorical:
+		stat.OptionCounts = map[string]int64{}
+		for _, o := range slot.optionKeys {
+			stat.OptionCounts[o] = 0
+		}
+		for _, resp := range responses {
+			v := resp.Responses[slot.key]
+			if v == "" {
+				continue
+			}
+			stat.Answered += 1
+			stat.OptionCounts[v] += 1
+		}
+	case statSlotMultipleChoice:
+		stat.OptionCounts = map[string]int64{}
+		for _, o := range slot.optionKeys {
+			stat.OptionCounts[o] = 0
+		}
+		for _, resp := range responses {
+			answered := false
+			for _, o := range slot.optionKeys {
+				v := resp.Responses[slot.optionCols[o]]
+				if v == "" {
+					continue
+				}
+				answered = true
+				if v == TRUE_VALUE {
+					stat.OptionCounts[o] += 1
+				}
+			}
+			if answered {
+				stat.Answered += 1
+			}
+		}
+	case statSlotNumeric:
+		values := []float64{}
+		for _, resp := range responses {
+			v := resp.Responses[slot.key]
+			if v == "" {
+				continue
+			}
+			stat.Answered += 1
+			num, err := strconv.ParseFloat(v, 64)
+			if err != nil || math.IsNaN(num) {
+				continue
+			}
+			values = append(values, num)
+		}
+		stat.Numeric = summariseNumbers(values, quantiles)
+	}
+	return stat
+}
+
+func summariseNumbers(values []float64, quantiles []float64) *NumericSummary {
+	summary := &NumericSummary{
+		Count:     int64(len(values)),
+		Quantiles: []Quantile{},
+	}
+	if len(values) < 1 {
+		return summary
+	}
+
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	summary.Min = sorted[0]
+	summary.Max = sorted[len(sorted)-1]
+	summary.Mean = sum / float64(len(sorted))
+
+	for _, p := range quantiles {
+		summary.Quantiles = append(summary.Quantiles, Quantile{
+			Probability: p,
+			Value:       quantileOfSorted(sorted, p),
+		})
+	}
+	return summary
+}
+
+// quantileOfSorted uses linear interpolation between closest ranks (same as R's default type 7)
+func quantileOfSorted(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 1 {
+		return sorted[len(sorted)-1]
+	}
+	h := p * float64(len(sorted)-1)
+	lower := math.Floor(h)
+	i := int(lower)
+	if i+1 >= len(sorted) {
+		return sorted[i]
+	}
+	return sorted[i] + (h-lower)*(sorted[i+1]-sorted[i])
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package response_parser
+
+import (
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetResponseStatistics(t *testing.T) {
+	testLang := "en"
+	questionOptionSep := "-"
+	testSurveyDef := &studyAPI.SurveyItem{
+		Key: "weekly",
+		Items: []*studyAPI.SurveyItem{
+			mockQuestion("weekly.Q1", testLang, "Title of Q1", mockSingleChoiceGroup(testLang, []MockOpionDef{
+				{Key: "1", Role: "option", Label: "Yes"},
+				{Key: "2", Role: "option", Label: "No"},
+			})),
+			mockQuestion("weekly.Q2", testLang, "Title of Q2", mockMultipleChoiceGroup(testLang, []MockOpionDef{
+				{Key: "1", Role: "option", Label: "Option 1"},
+				{Key: "2", Role: "option", Label: "Option 2"},
+			})),
+			mockQuestion("weekly.Q3", testLang, "Title of Q3", mockNumberInput(testLang, "Age")),
+		},
+	}
+	testSurvey := studyAPI.Survey{
+		Id: "surveyIDfromDB",
+		Current: &studyAPI.SurveyVersion{
+			Published:        10,
+			VersionId:        "1",
+			SurveyDefinition: testSurveyDef,
+		},
+	}
+	parser, err := NewResponseParser(&testSurvey, "en", true, questionOptionSep, nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	mockResponse := func(pID string, scg string, mcg []string, num string) *studyAPI.SurveyResponse {
+		mcgItems := []*studyAPI.ResponseItem{}
+		for _, k := range mcg {
+			mcgItems = append(mcgItems, &studyAPI.ResponseItem{Key: k})
+		}
+		return &studyAPI.SurveyResponse{Key: "weekly", ParticipantId: pID, SubmittedAt: 20, VersionId: "1",
+			Responses: []*studyAPI.SurveyItemResponse{
+				{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+					{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: scg}}},
+				}}},
+				{Key: "weekly.Q2", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+					{Key: "mcg", Items: mcgItems},
+				}}},
+				{Key: "weekly.Q3", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+					{Key: "num", Value: num},
+				}}},
+			},
+		}
+	}
+
+	for _, r := range []*studyAPI.SurveyResponse{
+		mockResponse("p1", "1", []string{"1", "2"}, "10"),
+		mockResponse("p2", "1", []string{"2"}, "20"),
+		mockResponse("p3", "2", []string{"2"}, "30"),
+		mockResponse("p4", "2", []string{"1"}, "40"),
+	} {
+		if err := parser.AddResponse(r); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	stats := parser.GetResponseStatistics(nil)
+	if len(stats) != 3 {
+		t.Errorf("unexpected number of question statistics: %d", len(stats))
+		return
+	}
+
+	t.Run("single choice", func(t *testing.T) {
+		s := stats[0]
+		if s.QuestionKey != "Q1" || s.ResponseCount != 4 || len(s.Slots) != 1 {
+			t.Errorf("unexpected statistics: %v", s)
+			return
+		}
+		if s.Slots[0].Answered != 4 || s.Slots[0].OptionCounts["1"] != 2 || s.Slots[0].OptionCounts["2"] != 2 {
+			t.Errorf("unexpected counts: %v", s.Slots[0])
+		}
+	})
+
+	t.Run("multiple choice", func(t *testing.T) {
+		s := stats[1]
+		if len(s.Slots) != 1 {
+			t.Errorf("unexpected statistics: %v", s)
+			return
+		}
+		if s.Slots[0].Answered != 4 || s.Slots[0].OptionCounts["1"] != 2 || s.Slots[0].OptionCounts["2"] != 3 {
+			t.Errorf("unexpected counts: %v", s.Slots[0])
+		}
+	})
+
+	t.Run("number input", func(t *testing.T) {
+		s := stats[2]
+		if len(s.Slots) != 1 || s.Slots[0].Numeric == nil {
+			t.Errorf("unexpected statistics: %v", s)
+			return
+		}
+		n := s.Slots[0].Numeric
+		if n.Count != 4 || n.Min != 10 || n.Max != 40 || n.Mean != 25 {
+			t.Errorf("unexpected summary: %v", n)
+			return
+		}
+		if len(n.Quantiles) != 3 || n.Quantiles[0].Value != 17.5 || n.Quantiles[1].Value != 25 || n.Quantiles[2].Value != 32.5 {
+			t.Errorf("unexpected quantiles: %v", n.Quantiles)
+		}
+	})
+}
+
+func TestQuantileOfSorted(t *testing.T) {
+	t.Run("with empty list", func(t *testing.T) {
+		if v := quantileOfSorted([]float64{}, 0.5); v != 0 {
+			t.Errorf("unexpected value: %f", v)
+		}
+	})
+
+	t.Run("with single value", func(t *testing.T) {
+		if v := quantileOfSorted([]float64{3}, 0.25); v != 3 {
+			t.Errorf("unexpected value: %f", v)
+		}
+	})
+
+	t.Run("with interpolation", func(t *testing.T) {
+		values := []float64{1, 2, 3, 4, 5}
+		if v := quantileOfSorted(values, 0.5); v != 3 {
+			t.Errorf("unexpected value: %f", v)
+		}
+		if v := quantileOfSorted(values, 0.1); v != 1.4 {
+			t.Errorf("unexpected value: %f", v)
+		}
+		if v := quantileOfSorted(values, 1); v != 5 {
+			t.Errorf("unexpected value: %f", v)
+		}
+	})
+}
@@ -2,13 +2,13 @@ package response_parser
 
 import (
 	"errors"
-	"log"
 	"strings"
 
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 )
 
-func surveyDefToVersionPreview(original *studyAPI.SurveyVersion, prefLang string) SurveyVersionPreview {
+func surveyDefToVersionPreview(original *studyAPI.SurveyVersion, prefLang string, logger *zap.Logger) SurveyVersionPreview {
 	sp := SurveyVersionPreview{
 		VersionID:   original.VersionId,
 		Published:   original.Published,
@@ -16,22 +16,29 @@ func surveyDefToVersionPreview(original *studyAPI.SurveyVersion, prefLang string
 		Questions:   []SurveyQuestion{},
 	}
 
-	sp.Questions = extractQuestions(original.SurveyDefinition, prefLang)
+	sp.Questions = extractQuestions(original.SurveyDefinition, prefLang, logger)
 	return sp
 }
 
-func extractQuestions(root *studyAPI.SurveyItem, prefLang string) []SurveyQuestion {
-	questions := []SurveyQuestion{}
+func extractQuestions(root *studyAPI.SurveyItem, prefLang string, logger *zap.Logger) []SurveyQuestion {
 	if root == nil {
-		return questions
+		return []SurveyQuestion{}
 	}
+	return extractGroupQuestions(root, prefLang, appendCondition(nil, root.Condition), logger)
+}
+
+// extractGroupQuestions collects the questions of a group, parentConditions are the display conditions
+// of the group and its parents
+func extractGroupQuestions(root *studyAPI.SurveyItem, prefLang string, parentConditions []*studyAPI.Expression, logger *zap.Logger) []SurveyQuestion {
+	questions := []SurveyQuestion{}
 	for _, item := range root.Items {
 		if item.Type == "pageBreak" {
 			continue
 		}
 
+		conditions := appendCondition(parentConditions, item.Condition)
 		if isItemGroup(item) {
-			questions = append(questions, extractQuestions(item, prefLang)...)
+			questions = append(questions, extractGroupQuestions(item, prefLang, conditions, logger)...)
 			continue
 		}
 
@@ -40,7 +47,7 @@ func extractQuestions(root *studyAPI.SurveyItem, prefLang string) []SurveyQuesti
 			continue
 		}
 
-		responses, qType := extractResponses(rg, prefLang)
+		responses, qType := extractResponses(rg, prefLang, logger)
 
 		titleComp := getTitleComponent(item)
 		title := ""
@@ -48,7 +55,7 @@ func extractQuestions(root *studyAPI.SurveyItem, prefLang string) []SurveyQuesti
 			var err error
 			title, err = getTranslation(titleComp.Content, prefLang)
 			if err != nil {
-				log.Printf("Question %s title error: %v", item.Key, err)
+				logger.Debug("question title not found", zap.String("question", item.Key), zap.String("lang", prefLang), zap.Error(err))
 			}
 		}
 
@@ -57,6 +64,9 @@ func extractQuestions(root *studyAPI.SurveyItem, prefLang string) []SurveyQuesti
 			Title:        title,
 			QuestionType: qType,
 			Responses:    responses,
+			Condition:    renderConditions(conditions),
+			Validations:  renderValidations(item.Validations),
+			conditions:   conditions,
 		}
 		questions = append(questions, question)
 	}
@@ -108,14 +118,14 @@ func getTranslation(content []*studyAPI.LocalisedObject, lang string) (string, e
 	return "", errors.New("translation missing")
 }
 
-func extractResponses(rg *studyAPI.ItemComponent, lang string) ([]ResponseDef, string) {
+func extractResponses(rg *studyAPI.ItemComponent, lang string, logger *zap.Logger) ([]ResponseDef, string) {
 	if rg == nil {
 		return []ResponseDef{}, QUESTION_TYPE_EMPTY
 	}
 
 	responses := []ResponseDef{}
 	for _, item := range rg.Items {
-		r := mapToResponseDef(item, rg.Key, lang)
+		r := mapToResponseDef(item, rg.Key, lang, logger)
 		responses = append(responses, r...)
 
 	}
@@ -125,9 +135,9 @@ func extractResponses(rg *studyAPI.ItemComponent, lang string) ([]ResponseDef, s
 
 }
 
-func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang string) []ResponseDef {
+func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang string, logger *zap.Logger) []ResponseDef {
 	if rItem == nil {
-		log.Println("mapToResponseDef: unexpected nil input")
+		logger.Warn("unexpected empty response component", zap.String("parent", parentKey))
 		return []ResponseDef{}
 	}
 
@@ -141,7 +151,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 		for _, o := range rItem.Items {
 			label, err := getTranslation(o.Content, lang)
 			if err != nil {
-				log.Printf("mapToResponseDef: label not found for: %v", o)
+				logLabelNotFound(logger, o, lang, err)
 			}
 			option := ResponseOption{
 				ID:    o.Key,
@@ -165,7 +175,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 		for _, o := range rItem.Items {
 			label, err := getTranslation(o.Content, lang)
 			if err != nil {
-				log.Printf("mapToResponseDef: label not found for: %v", o)
+				logLabelNotFound(logger, o, lang, err)
 			}
 			option := ResponseOption{
 				ID:    o.Key,
@@ -189,7 +199,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 		for _, o := range rItem.Items {
 			label, err := getTranslation(o.Content, lang)
 			if err != nil {
-				log.Printf("mapToResponseDef: label not found for: %v", o)
+				logLabelNotFound(logger, o, lang, err)
 			}
 			option := ResponseOption{
 				ID:    o.Key,
@@ -203,7 +213,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 	case "input":
 		label, err := getTranslation(rItem.Content, lang)
 		if err != nil {
-			log.Printf("mapToResponseDef: label not found for: %v", rItem)
+			logLabelNotFound(logger, rItem, lang, err)
 		}
 		responseDef.Label = label
 		responseDef.ResponseType = QUESTION_TYPE_TEXT_INPUT
@@ -211,7 +221,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 	case "multilineTextInput":
 		label, err := getTranslation(rItem.Content, lang)
 		if err != nil {
-			log.Printf("mapToResponseDef: label not found for: %v", rItem)
+			logLabelNotFound(logger, rItem, lang, err)
 		}
 		responseDef.Label = label
 		responseDef.ResponseType = QUESTION_TYPE_TEXT_INPUT
@@ -219,15 +229,16 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 	case "numberInput":
 		label, err := getTranslation(rItem.Content, lang)
 		if err != nil {
-			log.Printf("mapToResponseDef: label not found for: %v", rItem)
+			logLabelNotFound(logger, rItem, lang, err)
 		}
 		responseDef.Label = label
 		responseDef.ResponseType = QUESTION_TYPE_NUMBER_INPUT
+		responseDef.Min, responseDef.Max = getNumberRange(rItem)
 		return []ResponseDef{responseDef}
 	case "dateInput":
 		label, err := getTranslation(rItem.Content, lang)
 		if err != nil {
-			log.Printf("mapToResponseDef: label not found for: %v", rItem)
+			logLabelNotFound(logger, rItem, lang, err)
 		}
 		responseDef.Label = label
 		responseDef.ResponseType = QUESTION_TYPE_DATE_INPUT
@@ -239,16 +250,17 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 	case "sliderNumeric":
 		label, err := getTranslation(rItem.Content, lang)
 		if err != nil {
-			log.Printf("mapToResponseDef: label not found for: %v", rItem)
+			logLabelNotFound(logger, rItem, lang, err)
 		}
 		responseDef.Label = label
 		responseDef.ResponseType = QUESTION_TYPE_NUMERIC_SLIDER
+		responseDef.Min, responseDef.Max = getNumberRange(rItem)
 		return []ResponseDef{responseDef}
 	case "likert":
 		for _, o := range rItem.Items {
 			label, err := getTranslation(o.Content, lang)
 			if err != nil {
-				log.Printf("mapToResponseDef: label not found for: %v", o)
+				logLabelNotFound(logger, o, lang, err)
 			}
 			option := ResponseOption{
 				ID:    o.Key,
@@ -295,7 +307,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 						for _, o := range col.Items {
 							dL, err := getTranslation(o.Content, lang)
 							if err != nil {
-								log.Printf("mapToResponseDef: label not found for: %v", o)
+								logLabelNotFound(logger, o, lang, err)
 							}
 							option := ResponseOption{
 								ID:    o.Key,
@@ -308,7 +320,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 					} else if col.Role == "input" {
 						label, err := getTranslation(col.Content, lang)
 						if err != nil {
-							log.Printf("mapToResponseDef: label not found for: %v", col)
+							logLabelNotFound(logger, col, lang, err)
 						}
 						currentResponseDef.ResponseType = QUESTION_TYPE_MATRIX_INPUT
 						currentResponseDef.Label = label
@@ -317,12 +329,13 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 					} else if col.Role == "numberInput" {
 						label, err := getTranslation(col.Content, lang)
 						if err != nil {
-							log.Printf("mapToResponseDef: label not found for: %v", col)
+							logLabelNotFound(logger, col, lang, err)
 						}
 						currentResponseDef.ResponseType = QUESTION_TYPE_MATRIX_NUMBER_INPUT
 						currentResponseDef.Label = label
+						currentResponseDef.Min, currentResponseDef.Max = getNumberRange(col)
 					} else {
-						log.Printf("mapToResponseDef: matrix cell role %s ignored.", col.Role)
+						logger.Warn("matrix cell ignored", zap.String("component", cellKey), zap.String("role", col.Role))
 						continue
 					}
 					responses = append(responses, currentResponseDef)
@@ -336,7 +349,7 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 					if o.Role == "label" {
 						label, err := getTranslation(o.Content, lang)
 						if err != nil {
-							log.Printf("mapToResponseDef: label not found for: %v", o)
+							logLabelNotFound(logger, o, lang, err)
 						}
 						currentResponseDef.Label = label
 					} else {
@@ -352,11 +365,41 @@ func mapToResponseDef(rItem *studyAPI.ItemComponent, parentKey string, lang stri
 		}
 		return responses
 	default:
-		log.Printf("mapToResponseDef: component with role is ignored: %s [%s]", rItem.Role, key)
+		logger.Warn("response component ignored", zap.String("component", key), zap.String("role", rItem.Role))
 		return []ResponseDef{}
 	}
 }
 
+// logLabelNotFound logs the component key and role instead of the whole component
+func logLabelNotFound(logger *zap.Logger, comp *studyAPI.ItemComponent, lang string, err error) {
+	logger.Debug("label not found",
+		zap.String("component", comp.Key),
+		zap.String("role", comp.Role),
+		zap.String("lang", lang),
+		zap.Error(err),
+	)
+}
+
+// getNumberRange reads the min and max properties of a numeric input, bounds given by expressions are ignored
+func getNumberRange(comp *studyAPI.ItemComponent) (min *float64, max *float64) {
+	if comp.Properties == nil {
+		return nil, nil
+	}
+	if arg := comp.Properties.Min; arg != nil {
+		if _, ok := arg.Data.(*studyAPI.ExpressionArg_Num); ok {
+			v := arg.GetNum()
+			min = &v
+		}
+	}
+	if arg := comp.Properties.Max; arg != nil {
+		if _, ok := arg.Data.(*studyAPI.ExpressionArg_Num); ok {
+			v := arg.GetNum()
+			max = &v
+		}
+	}
+	return min, max
+}
+
 func getQuestionType(responses []ResponseDef) string {
 	var qType string
 	if len(responses) < 1 {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"go.uber.org/zap"
 )
 
 func TestIsItemGroup(t *testing.T) {
@@ -133,7 +134,7 @@ func TestGetTranslation(t *testing.T) {
 func TestExtractResponses(t *testing.T) {
 	testLang := "en"
 	t.Run("missing response group component", func(t *testing.T) {
-		ro, qType := extractResponses(nil, testLang)
+		ro, qType := extractResponses(nil, testLang, zap.NewNop())
 		if len(ro) > 0 {
 			t.Error("should be empty")
 		}
@@ -148,7 +149,7 @@ func TestExtractResponses(t *testing.T) {
 			Role:  "responseGroup",
 			Items: []*studyAPI.ItemComponent{},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) > 0 {
 			t.Error("should be empty")
 		}
@@ -167,7 +168,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "3", Role: "more"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) > 0 {
 			t.Error("should be empty")
 		}
@@ -189,7 +190,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -211,7 +212,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -241,7 +242,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 2 {
 			t.Error("shouldn't be empty")
 		}
@@ -270,7 +271,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 2 {
 			t.Error("shouldn't be empty")
 		}
@@ -287,7 +288,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "1", Role: "dateInput"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -304,7 +305,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "1", Role: "input"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -321,7 +322,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "1", Role: "numberInput"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -338,7 +339,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "1", Role: "eq5d-health-indicator"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -355,7 +356,7 @@ func TestExtractResponses(t *testing.T) {
 				{Key: "1", Role: "sliderNumeric"},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -376,7 +377,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 1 {
 			t.Error("shouldn't be empty")
 		}
@@ -417,7 +418,7 @@ func TestExtractResponses(t *testing.T) {
 				}},
 			},
 		}
-		ro, qType := extractResponses(rg, testLang)
+		ro, qType := extractResponses(rg, testLang, zap.NewNop())
 		if len(ro) != 5 {
 			t.Error("shouldn't be empty")
 		}
@@ -91,3 +91,13 @@ func mockLikertGroup(lang string, categoryLabels []MockOpionDef, optionLabels []
 	}
 	return &rg
 }
+
+func mockNumberInput(lang string, label string) *studyAPI.ItemComponent {
+	return &studyAPI.ItemComponent{
+		Key:  "rg",
+		Role: "responseGroup", Items: []*studyAPI.ItemComponent{
+			{Key: "num", Role: "numberInput", Content: []*studyAPI.LocalisedObject{
+				{Code: lang, Parts: []*studyAPI.ExpressionArg{{Data: &studyAPI.ExpressionArg_Str{Str: label}}}},
+			}},
+		}}
+}
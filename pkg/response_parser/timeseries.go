@@ -0,0 +1,72 @@
+package response_parser
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// GetResponseTimeSeries groups the responses by the time bucket of their submission and computes
+// the statistics of the selected questions for each bucket.
+func (rp ResponseParser) GetResponseTimeSeries(
+	bucket string,
+	loc *time.Location,
+	questionKeys []string,
+	quantiles []float64,
+) ([]TimeSeriesBucket, error) {
+	if bucket == "" {
+		bucket = TIME_BUCKET_WEEK
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	bucketsByLabel := map[string]*TimeSeriesBucket{}
+	responsesByLabel := map[string][]ParsedResponse{}
+	for _, resp := range rp.responses {
+		label, start, end, err := getTimeBucket(resp.SubmittedAt, bucket, loc)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := bucketsByLabel[label]; !ok {
+			bucketsByLabel[label] = &TimeSeriesBucket{
+				Label: label,
+				Start: start,
+				End:   end,
+			}
+		}
+		bucketsByLabel[label].ResponseCount += 1
+		responsesByLabel[label] = append(responsesByLabel[label], resp)
+	}
+
+	buckets := []TimeSeriesBucket{}
+	for label, b := range bucketsByLabel {
+		b.Questions = rp.computeStatistics(responsesByLabel[label], questionKeys, quantiles)
+		buckets = append(buckets, *b)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Start < buckets[j].Start
+	})
+	return buckets, nil
+}
+
+// getTimeBucket returns the label and the [start, end) boundaries of the bucket containing the timestamp
+func getTimeBucket(ts int64, bucket string, loc *time.Location) (label string, start int64, end int64, err error) {
+	t := time.Unix(ts, 0).In(loc)
+	switch bucket {
+	case TIME_BUCKET_DAY:
+		s := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		return s.Format("2006-01-02"), s.Unix(), s.AddDate(0, 0, 1).Unix(), nil
+	case TIME_BUCKET_WEEK:
+		year, week := t.ISOWeek()
+		// ISO weeks start on monday
+		daysSinceMonday := (int(t.Weekday()) + 6) % 7
+		s := time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, loc)
+		return fmt.Sprintf("%d-W%02d", year, week), s.Unix(), s.AddDate(0, 0, 7).Unix(), nil
+	case TIME_BUCKET_MONTH:
+		s := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		return s.Format("2006-01"), s.Unix(), s.AddDate(0, 1, 0).Unix(), nil
+	default:
+		return "", 0, 0, fmt.Errorf("unknown time bucket: %s", bucket)
+	}
+}
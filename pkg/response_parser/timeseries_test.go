@@ -0,0 +1,156 @@
+package response_parser
+
+import (
+	"testing"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetTimeBucket(t *testing.T) {
+	// Wednesday, 2021-02-03 10:00:00 UTC
+	ts := time.Date(2021, 2, 3, 10, 0, 0, 0, time.UTC).Unix()
+
+	t.Run("with unknown bucket", func(t *testing.T) {
+		_, _, _, err := getTimeBucket(ts, "year", time.UTC)
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with day", func(t *testing.T) {
+		label, start, end, err := getTimeBucket(ts, TIME_BUCKET_DAY, time.UTC)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if label != "2021-02-03" || start != time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC).Unix() || end-start != 24*3600 {
+			t.Errorf("unexpected bucket: %s %d %d", label, start, end)
+		}
+	})
+
+	t.Run("with week", func(t *testing.T) {
+		label, start, end, err := getTimeBucket(ts, TIME_BUCKET_WEEK, time.UTC)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if label != "2021-W05" || start != time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC).Unix() || end-start != 7*24*3600 {
+			t.Errorf("unexpected bucket: %s %d %d", label, start, end)
+		}
+	})
+
+	t.Run("with week at year boundary", func(t *testing.T) {
+		// Sunday, 2021-01-03 belongs to the last ISO week of 2020
+		label, _, _, err := getTimeBucket(time.Date(2021, 1, 3, 12, 0, 0, 0, time.UTC).Unix(), TIME_BUCKET_WEEK, time.UTC)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if label != "2020-W53" {
+			t.Errorf("unexpected label: %s", label)
+		}
+	})
+
+	t.Run("with month", func(t *testing.T) {
+		label, start, _, err := getTimeBucket(ts, TIME_BUCKET_MONTH, time.UTC)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if label != "2021-02" || start != time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC).Unix() {
+			t.Errorf("unexpected bucket: %s %d", label, start)
+		}
+	})
+
+	t.Run("with timezone", func(t *testing.T) {
+		// Sunday 23:30 UTC is already monday in UTC+1
+		loc := time.FixedZone("UTC+1", 3600)
+		label, _, _, err := getTimeBucket(time.Date(2021, 2, 7, 23, 30, 0, 0, time.UTC).Unix(), TIME_BUCKET_WEEK, loc)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if label != "2021-W06" {
+			t.Errorf("unexpected label: %s", label)
+		}
+	})
+}
+
+func TestGetResponseTimeSeries(t *testing.T) {
+	testLang := "en"
+	testSurvey := studyAPI.Survey{
+		Id: "surveyIDfromDB",
+		Current: &studyAPI.SurveyVersion{
+			Published: 10,
+			VersionId: "1",
+			SurveyDefinition: &studyAPI.SurveyItem{
+				Key: "weekly",
+				Items: []*studyAPI.SurveyItem{
+					mockQuestion("weekly.Q1", testLang, "Title of Q1", mockSingleChoiceGroup(testLang, []MockOpionDef{
+						{Key: "1", Role: "option", Label: "Yes"},
+						{Key: "2", Role: "option", Label: "No"},
+					})),
+					mockQuestion("weekly.Q2", testLang, "Title of Q2", mockNumberInput(testLang, "Age")),
+				},
+			},
+		},
+	}
+	parser, err := NewResponseParser(&testSurvey, "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	for i, submitted := range []time.Time{
+		time.Date(2021, 2, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, 2, 3, 10, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 27, 10, 0, 0, 0, time.UTC),
+	} {
+		err := parser.AddResponse(&studyAPI.SurveyResponse{Key: "weekly", ParticipantId: "p1", SubmittedAt: submitted.Unix(), VersionId: "1",
+			Responses: []*studyAPI.SurveyItemResponse{
+				{Key: "weekly.Q1", Response: &studyAPI.ResponseItem{Key: "rg", Items: []*studyAPI.ResponseItem{
+					{Key: "scg", Items: []*studyAPI.ResponseItem{{Key: []string{"1", "2", "1"}[i]}}},
+				}}},
+			},
+		})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+	}
+
+	t.Run("with unknown bucket", func(t *testing.T) {
+		_, err := parser.GetResponseTimeSeries("year", time.UTC, nil, nil)
+		if err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with weekly buckets and question filter", func(t *testing.T) {
+		buckets, err := parser.GetResponseTimeSeries(TIME_BUCKET_WEEK, time.UTC, []string{"Q1"}, nil)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		if len(buckets) != 2 {
+			t.Errorf("unexpected number of buckets: %d", len(buckets))
+			return
+		}
+		if buckets[0].Label != "2021-W04" || buckets[0].ResponseCount != 1 {
+			t.Errorf("unexpected first bucket: %v", buckets[0])
+		}
+		if buckets[1].Label != "2021-W05" || buckets[1].ResponseCount != 2 {
+			t.Errorf("unexpected second bucket: %v", buckets[1])
+			return
+		}
+		if len(buckets[1].Questions) != 1 {
+			t.Errorf("unexpected questions: %v", buckets[1].Questions)
+			return
+		}
+		counts := buckets[1].Questions[0].Slots[0].OptionCounts
+		if counts["1"] != 1 || counts["2"] != 1 {
+			t.Errorf("unexpected counts: %v", counts)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package response_parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// SetTimestampFormat configures how the submission time, the meta timestamps and date inputs are written.
+// It is used by all writers and has to be set before adding responses.
+func (rp *ResponseParser) SetTimestampFormat(tf TimestampFormat) error {
+	if tf.Format == "" {
+		tf.Format = TIME_FORMAT_UNIX
+	}
+	if tf.Format != TIME_FORMAT_UNIX && tf.Format != TIME_FORMAT_ISO8601 {
+		return fmt.Errorf("unknown timestamp format: %s", tf.Format)
+	}
+	if tf.Timezone == "" {
+		tf.Timezone = "UTC"
+	}
+	loc, err := time.LoadLocation(tf.Timezone)
+	if err != nil {
+		return fmt.Errorf("unknown timezone: %s", tf.Timezone)
+	}
+
+	rp.timestampFormat = tf
+	rp.timestampLocation = loc
+	rp.dateColumns = map[string]bool{}
+	if tf.DateInputsAsDate {
+		for _, sv := range rp.surveyVersions {
+			for _, q := range sv.Questions {
+				for _, k := range getDateColumns(q, rp.questionOptionKeySep) {
+					rp.dateColumns[k] = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (rp ResponseParser) formatTimestamp(ts int64) string {
+	if rp.timestampFormat.Format != TIME_FORMAT_ISO8601 {
+		return fmt.Sprint(ts)
+	}
+	return time.Unix(ts, 0).In(rp.timestampLocation).Format(time.RFC3339)
+}
+
+func (rp ResponseParser) formatTimestamps(ts []int64) string {
+	isISO := rp.timestampFormat.Format == TIME_FORMAT_ISO8601
+	if !isISO && !rp.arrayEncoding.JSON {
+		return timestampsToStr(ts, rp.arrayEncoding.Separator)
+	}
+	b := make([]string, len(ts))
+	for i, v := range ts {
+		b[i] = rp.formatTimestamp(v)
+	}
+	return rp.encodeArray(b, isISO)
+}
+
+// submittedCols are the column names and values for the submission time
+func (rp ResponseParser) submittedCols(ts int64) (header []string, values []string) {
+	if !rp.timestampFormat.SeparateDateTime {
+		return []string{"submitted"}, []string{rp.formatTimestamp(ts)}
+	}
+	t := time.Unix(ts, 0).In(rp.timestampLocation)
+	return []string{"submittedDate", "submittedTime"}, []string{t.Format("2006-01-02"), t.Format("15:04:05")}
+}
+
+// formatDateInput writes a date input answer as YYYY-MM-DD, other values are kept as they are
+func (rp ResponseParser) formatDateInput(colName string, value string) string {
+	if !rp.dateColumns[colName] || value == "" {
+		return value
+	}
+	ts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+	return time.Unix(ts, 0).In(rp.timestampLocation).Format("2006-01-02")
+}
+
+// getDateColumns lists the response columns of a question that contain the value of a date input
+func getDateColumns(question SurveyQuestion, sep string) []string {
+	return getInputColumns(question, sep, QUESTION_TYPE_DATE_INPUT, OPTION_TYPE_DATE_INPUT)
+}
@@ -0,0 +1,145 @@
+package response_parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetDateColumns(t *testing.T) {
+	testCases := []struct {
+		name     string
+		question SurveyQuestion
+		expected []string
+	}{
+		{
+			name: "date input",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_DATE_INPUT, Responses: []ResponseDef{
+				{ID: "date", ResponseType: QUESTION_TYPE_DATE_INPUT},
+			}},
+			expected: []string{"Q1"},
+		},
+		{
+			name: "single choice with date option",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_SINGLE_CHOICE, Responses: []ResponseDef{
+				{ID: "scg", ResponseType: QUESTION_TYPE_SINGLE_CHOICE, Options: []ResponseOption{
+					{ID: "1", OptionType: OPTION_TYPE_RADIO},
+					{ID: "2", OptionType: OPTION_TYPE_DATE_INPUT},
+				}},
+			}},
+			expected: []string{"Q1-2"},
+		},
+		{
+			name: "multiple choice with date option",
+			question: SurveyQuestion{ID: "Q1", QuestionType: QUESTION_TYPE_MULTIPLE_CHOICE, Responses: []ResponseDef{
+				{ID: "mcg", ResponseType: QUESTION_TYPE_MULTIPLE_CHOICE, Options: []ResponseOption{
+					{ID: "2", OptionType: OPTION_TYPE_DATE_INPUT},
+				}},
+			}},
+			expected: []string{"Q1-2-open"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cols := getDateColumns(tc.question, "-")
+			if strings.Join(cols, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("unexpected columns: %v", cols)
+			}
+		})
+	}
+}
+
+func TestTimestampFormat(t *testing.T) {
+	survey := mockWeeklyILISurvey("en")
+	survey.Current.SurveyDefinition.Items = append(survey.Current.SurveyDefinition.Items,
+		mockQuestion("weekly.Q3", "en", "Onset", &studyAPI.ItemComponent{
+			Key:  "rg",
+			Role: "responseGroup", Items: []*studyAPI.ItemComponent{
+				{Key: "date", Role: "dateInput"},
+			}}),
+	)
+
+	parser, err := NewResponseParser(survey, "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+
+	t.Run("with unknown format", func(t *testing.T) {
+		if err := parser.SetTimestampFormat(TimestampFormat{Format: "rfc822"}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	t.Run("with unknown timezone", func(t *testing.T) {
+		if err := parser.SetTimestampFormat(TimestampFormat{Timezone: "Mars/Olympus"}); err == nil {
+			t.Error("should fail with error")
+		}
+	})
+
+	if err := parser.SetTimestampFormat(TimestampFormat{
+		Format:           TIME_FORMAT_ISO8601,
+		Timezone:         "Europe/Berlin",
+		SeparateDateTime: true,
+		DateInputsAsDate: true,
+	}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	submitted := time.Date(2021, 2, 1, 23, 30, 0, 0, time.UTC).Unix()
+	onset := time.Date(2021, 1, 28, 0, 0, 0, 0, time.UTC).Unix()
+	resp := mockWeeklyILIResponse("p1", submitted, []string{"fever"}, "yes")
+	resp.Responses[0].Meta = &studyAPI.ResponseMeta{Displayed: []int64{submitted - 60}}
+	resp.Responses = append(resp.Responses, &studyAPI.SurveyItemResponse{Key: "weekly.Q3", Response: &studyAPI.ResponseItem{
+		Key: "rg", Items: []*studyAPI.ResponseItem{{Key: "date", Value: strconv.FormatInt(onset, 10)}},
+	}})
+	if err := parser.AddResponse(resp); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		if err := parser.GetResponsesCSV(buf, true); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if !strings.HasPrefix(lines[0], "participantID,version,submittedDate,submittedTime,") {
+			t.Errorf("unexpected header: %s", lines[0])
+		}
+		if !strings.HasPrefix(lines[1], "p1,1,2021-02-02,00:30:00,") {
+			t.Errorf("unexpected line: %s", lines[1])
+		}
+		if !strings.Contains(lines[1], ",2021-01-28,") {
+			t.Errorf("date input not formatted: %s", lines[1])
+		}
+		if !strings.Contains(lines[1], ",2021-02-02T00:29:00+01:00,") {
+			t.Errorf("meta timestamp not formatted: %s", lines[1])
+		}
+	})
+
+	t.Run("quality report", func(t *testing.T) {
+		parser.addQualityIssue(resp, QUALITY_ISSUE_DUPLICATE, "", "test")
+		buf := new(bytes.Buffer)
+		if err := parser.GetDataQualityReportJSON(buf); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		report := map[string]interface{}{}
+		if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+			t.Errorf("unexpected error: %v", err)
+			return
+		}
+		issue := report["issues"].([]interface{})[0].(map[string]interface{})
+		if issue["submitted"] != "2021-02-02T00:30:00+01:00" {
+			t.Errorf("unexpected submitted: %v", issue["submitted"])
+		}
+	})
+}
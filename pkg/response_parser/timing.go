@@ -0,0 +1,110 @@
+package response_parser
+
+import (
+	"strconv"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+const (
+	TIMING_COL_TIME_TO_ANSWER = "timeToAnswer"
+	TIMING_COL_ANSWER_CHANGES = "answerChanges"
+	TIMING_COL_UNANSWERED     = "displayedNotAnswered"
+	TIMING_COL_DURATION       = "surveyDuration"
+)
+
+// SetIncludeTiming enables the timing columns for responses added afterwards.
+// Durations use the same unit as the timestamps in the item meta.
+func (rp *ResponseParser) SetIncludeTiming(include bool) {
+	rp.includeTiming = include
+}
+
+func (rp *ResponseParser) AddTimingColName(name string) {
+	for _, n := range rp.timingColNames {
+		if n == name {
+			return
+		}
+	}
+	rp.timingColNames = append(rp.timingColNames, name)
+}
+
+// getQuestionTiming computes the time from first display to last response, the number of times the
+// response was changed and whether the question was displayed but never answered
+func getQuestionTiming(questionKey string, resp *studyAPI.SurveyItemResponse, questionOptionSep string) map[string]string {
+	timingCols := map[string]string{
+		questionKey + questionOptionSep + TIMING_COL_TIME_TO_ANSWER: "",
+		questionKey + questionOptionSep + TIMING_COL_ANSWER_CHANGES: "",
+		questionKey + questionOptionSep + TIMING_COL_UNANSWERED:     "",
+	}
+	if resp == nil || resp.Meta == nil {
+		return timingCols
+	}
+
+	firstDisplayed, hasDisplayed := minTimestamp(resp.Meta.Displayed)
+	lastResponded, hasResponded := maxTimestamp(resp.Meta.Responded)
+	if hasDisplayed && hasResponded {
+		timingCols[questionKey+questionOptionSep+TIMING_COL_TIME_TO_ANSWER] = strconv.FormatInt(lastResponded-firstDisplayed, 10)
+	}
+
+	changes := 0
+	if len(resp.Meta.Responded) > 1 {
+		changes = len(resp.Meta.Responded) - 1
+	}
+	timingCols[questionKey+questionOptionSep+TIMING_COL_ANSWER_CHANGES] = strconv.Itoa(changes)
+
+	unanswered := FALSE_VALUE
+	if hasDisplayed && !hasResponded {
+		unanswered = TRUE_VALUE
+	}
+	timingCols[questionKey+questionOptionSep+TIMING_COL_UNANSWERED] = unanswered
+	return timingCols
+}
+
+// getSurveyDuration is the time between the first display and the last response of any item
+func getSurveyDuration(responses []*studyAPI.SurveyItemResponse) string {
+	var first, last int64
+	hasFirst, hasLast := false, false
+	for _, r := range responses {
+		if r == nil || r.Meta == nil {
+			continue
+		}
+		if d, ok := minTimestamp(r.Meta.Displayed); ok && (!hasFirst || d < first) {
+			first = d
+			hasFirst = true
+		}
+		if l, ok := maxTimestamp(r.Meta.Responded); ok && (!hasLast || l > last) {
+			last = l
+			hasLast = true
+		}
+	}
+	if !hasFirst || !hasLast {
+		return ""
+	}
+	return strconv.FormatInt(last-first, 10)
+}
+
+func minTimestamp(ts []int64) (int64, bool) {
+	if len(ts) == 0 {
+		return 0, false
+	}
+	m := ts[0]
+	for _, v := range ts[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m, true
+}
+
+func maxTimestamp(ts []int64) (int64, bool) {
+	if len(ts) == 0 {
+		return 0, false
+	}
+	m := ts[0]
+	for _, v := range ts[1:] {
+		if v > m {
+			m = v
+		}
+	}
+	return m, true
+}
@@ -0,0 +1,89 @@
+package response_parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
+
+func TestGetQuestionTiming(t *testing.T) {
+	t.Run("without response", func(t *testing.T) {
+		cols := getQuestionTiming("Q1", nil, "-")
+		if len(cols) != 3 || cols["Q1-timeToAnswer"] != "" || cols["Q1-answerChanges"] != "" || cols["Q1-displayedNotAnswered"] != "" {
+			t.Errorf("unexpected columns: %v", cols)
+		}
+	})
+
+	t.Run("with answered question", func(t *testing.T) {
+		cols := getQuestionTiming("Q1", &studyAPI.SurveyItemResponse{Key: "Q1", Meta: &studyAPI.ResponseMeta{
+			Rendered:  []int64{90},
+			Displayed: []int64{120, 100},
+			Responded: []int64{110, 130, 125},
+		}}, "-")
+		if cols["Q1-timeToAnswer"] != "30" || cols["Q1-answerChanges"] != "2" || cols["Q1-displayedNotAnswered"] != FALSE_VALUE {
+			t.Errorf("unexpected columns: %v", cols)
+		}
+	})
+
+	t.Run("with displayed but not answered question", func(t *testing.T) {
+		cols := getQuestionTiming("Q1", &studyAPI.SurveyItemResponse{Key: "Q1", Meta: &studyAPI.ResponseMeta{
+			Displayed: []int64{100},
+		}}, "-")
+		if cols["Q1-timeToAnswer"] != "" || cols["Q1-answerChanges"] != "0" || cols["Q1-displayedNotAnswered"] != TRUE_VALUE {
+			t.Errorf("unexpected columns: %v", cols)
+		}
+	})
+}
+
+func TestGetSurveyDuration(t *testing.T) {
+	t.Run("without meta", func(t *testing.T) {
+		if d := getSurveyDuration([]*studyAPI.SurveyItemResponse{{Key: "Q1"}}); d != "" {
+			t.Errorf("unexpected duration: %s", d)
+		}
+	})
+
+	t.Run("with multiple items", func(t *testing.T) {
+		d := getSurveyDuration([]*studyAPI.SurveyItemResponse{
+			{Key: "Q1", Meta: &studyAPI.ResponseMeta{Displayed: []int64{100}, Responded: []int64{110}}},
+			{Key: "Q2", Meta: &studyAPI.ResponseMeta{Displayed: []int64{105}}},
+			{Key: "Q3", Meta: &studyAPI.ResponseMeta{Displayed: []int64{115}, Responded: []int64{160}}},
+		})
+		if d != "60" {
+			t.Errorf("unexpected duration: %s", d)
+		}
+	})
+}
+
+func TestTimingColumns(t *testing.T) {
+	parser, err := NewResponseParser(mockWeeklyILISurvey("en"), "en", true, "-", nil)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err.Error())
+		return
+	}
+	parser.SetIncludeTiming(true)
+
+	resp := mockWeeklyILIResponse("p1", 100, []string{"cough"}, "yes")
+	resp.Responses[0].Meta = &studyAPI.ResponseMeta{Displayed: []int64{10}, Responded: []int64{15, 20}}
+	resp.Responses[1].Meta = &studyAPI.ResponseMeta{Displayed: []int64{20}}
+	if err := parser.AddResponse(resp); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+
+	r := parser.GetResponses()[0]
+	if r.Timing["Q1-timeToAnswer"] != "10" || r.Timing["Q1-answerChanges"] != "1" || r.Timing["Q2-displayedNotAnswered"] != TRUE_VALUE || r.Timing["surveyDuration"] != "10" {
+		t.Errorf("unexpected timing values: %v", r.Timing)
+	}
+
+	buf := new(bytes.Buffer)
+	if err := parser.GetResponsesCSV(buf, false); err != nil {
+		t.Errorf("unexpected error: %v", err)
+		return
+	}
+	header := strings.Split(buf.String(), "\n")[0]
+	if !strings.Contains(header, "Q1-timeToAnswer") || !strings.Contains(header, "surveyDuration") {
+		t.Errorf("unexpected header: %s", header)
+	}
+}
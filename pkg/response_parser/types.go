@@ -1,6 +1,9 @@
 package response_parser
 
-import "github.com/influenzanet/data-service/pkg/api"
+import (
+	"github.com/influenzanet/data-service/pkg/api"
+	studyAPI "github.com/influenzanet/study-service/pkg/api"
+)
 
 const (
 	QUESTION_TYPE_SINGLE_CHOICE       = "single_choice"
@@ -41,6 +44,54 @@ const (
 	FALSE_VALUE           = "FALSE"
 )
 
+const (
+	CASE_RULE_AND       = "and"
+	CASE_RULE_OR        = "or"
+	CASE_RULE_NOT       = "not"
+	CASE_RULE_HAS_VALUE = "hasValue"
+	CASE_RULE_EQUALS    = "equals"
+	CASE_RULE_IS_TRUE   = "isTrue"
+)
+
+const (
+	TIME_BUCKET_DAY   = "day"
+	TIME_BUCKET_WEEK  = "week"
+	TIME_BUCKET_MONTH = "month"
+)
+
+const (
+	QUALITY_ISSUE_DUPLICATE            = "duplicate"
+	QUALITY_ISSUE_UNMAPPED_VERSION     = "unmappedVersion"
+	QUALITY_ISSUE_UNKNOWN_KEY          = "unknownKey"
+	QUALITY_ISSUE_OUT_OF_RANGE         = "outOfRange"
+	QUALITY_ISSUE_UNEXPECTED_SELECTION = "unexpectedSelectionCount"
+	QUALITY_ISSUE_HIDDEN_ANSWERED      = "answeredWhileHidden"
+)
+
+const (
+	TIME_FORMAT_UNIX    = "unix"
+	TIME_FORMAT_ISO8601 = "iso8601"
+)
+
+const (
+	MISSING_NOT_IN_VERSION = "notInVersion"
+	MISSING_NOT_SHOWN      = "notShown"
+	MISSING_SKIPPED        = "skipped"
+	MISSING_ANSWERED_EMPTY = "answeredEmpty"
+)
+
+const (
+	DEDUP_POLICY_NONE       = ""
+	DEDUP_POLICY_KEEP_FIRST = "keepFirst"
+	DEDUP_POLICY_KEEP_LAST  = "keepLast"
+	DEDUP_POLICY_KEEP_ALL   = "keepAll"
+)
+
+const (
+	REPORT_FORMAT_JSON = "json"
+	REPORT_FORMAT_CSV  = "csv"
+)
+
 type SurveyVersionPreview struct {
 	VersionID   string
 	Published   int64
@@ -66,6 +117,9 @@ type SurveyQuestion struct {
 	Title        string
 	QuestionType string
 	Responses    []ResponseDef
+	Condition    string
+	Validations  []string
+	conditions   []*studyAPI.Expression // own and parent group conditions, used for consistency checks
 }
 
 func (q SurveyQuestion) ToAPI() *api.SurveyQuestion {
@@ -73,6 +127,8 @@ func (q SurveyQuestion) ToAPI() *api.SurveyQuestion {
 		Key:          q.ID,
 		QuestionType: q.QuestionType,
 		Title:        q.Title,
+		Condition:    q.Condition,
+		Validations:  q.Validations,
 	}
 	v.Responses = make([]*api.ResponseDef, len(q.Responses))
 	for i, r := range q.Responses {
@@ -86,6 +142,8 @@ type ResponseDef struct {
 	ResponseType string
 	Label        string
 	Options      []ResponseOption
+	Min          *float64 // only set for numeric inputs with a fixed lower bound
+	Max          *float64 // only set for numeric inputs with a fixed upper bound
 }
 
 func (rd ResponseDef) ToAPI() *api.ResponseDef {
@@ -121,7 +179,11 @@ type ParsedResponse struct {
 	Version       string
 	Context       map[string]string // e.g. Language, or engine version
 	Responses     map[string]string
+	Derived       map[string]string
+	Timing        map[string]string
+	Missing       map[string]string // reason for each empty response column
 	Meta          ResponseMeta
+	DuplicateOf   int64 // submission time of the first response of the duplicate group, 0 if no duplicate
 }
 
 type ResponseMeta struct {
@@ -130,3 +192,339 @@ type ResponseMeta struct {
 	Responded   map[string]string
 	ItemVersion map[string]string
 }
+
+type QuestionStatistics struct {
+	VersionID     string
+	QuestionKey   string
+	QuestionType  string
+	ResponseCount int64
+	Slots         []ResponseSlotStatistics
+}
+
+func (qs QuestionStatistics) ToAPI() *api.QuestionStatistics {
+	v := &api.QuestionStatistics{
+		VersionId:     qs.VersionID,
+		QuestionKey:   qs.QuestionKey,
+		QuestionType:  qs.QuestionType,
+		ResponseCount: qs.ResponseCount,
+	}
+	v.Slots = make([]*api.ResponseSlotStatistics, len(qs.Slots))
+	for i, s := range qs.Slots {
+		v.Slots[i] = s.ToAPI()
+	}
+	return v
+}
+
+type ResponseSlotStatistics struct {
+	Key          string
+	Answered     int64
+	OptionCounts map[string]int64
+	Numeric      *NumericSummary
+}
+
+func (ss ResponseSlotStatistics) ToAPI() *api.ResponseSlotStatistics {
+	v := &api.ResponseSlotStatistics{
+		Key:          ss.Key,
+		Answered:     ss.Answered,
+		OptionCounts: ss.OptionCounts,
+	}
+	if ss.Numeric != nil {
+		v.Numeric = ss.Numeric.ToAPI()
+	}
+	return v
+}
+
+type NumericSummary struct {
+	Count     int64
+	Min       float64
+	Max       float64
+	Mean      float64
+	Quantiles []Quantile
+}
+
+func (ns NumericSummary) ToAPI() *api.NumericSummary {
+	v := &api.NumericSummary{
+		Count: ns.Count,
+		Min:   ns.Min,
+		Max:   ns.Max,
+		Mean:  ns.Mean,
+	}
+	v.Quantiles = make([]*api.Quantile, len(ns.Quantiles))
+	for i, q := range ns.Quantiles {
+		v.Quantiles[i] = &api.Quantile{
+			Probability: q.Probability,
+			Value:       q.Value,
+		}
+	}
+	return v
+}
+
+type Quantile struct {
+	Probability float64
+	Value       float64
+}
+
+type TimeSeriesBucket struct {
+	Label         string
+	Start         int64
+	End           int64
+	ResponseCount int64
+	Questions     []QuestionStatistics
+}
+
+func (tb TimeSeriesBucket) ToAPI() *api.TimeSeriesBucket {
+	v := &api.TimeSeriesBucket{
+		Label:         tb.Label,
+		Start:         tb.Start,
+		End:           tb.End,
+		ResponseCount: tb.ResponseCount,
+	}
+	v.Questions = make([]*api.QuestionStatistics, len(tb.Questions))
+	for i, q := range tb.Questions {
+		v.Questions[i] = q.ToAPI()
+	}
+	return v
+}
+
+type CaseDefinition struct {
+	Name string
+	Rule CaseRule
+}
+
+func CaseDefinitionFromAPI(cd *api.CaseDefinition) CaseDefinition {
+	if cd == nil {
+		return CaseDefinition{}
+	}
+	return CaseDefinition{
+		Name: cd.Name,
+		Rule: CaseRuleFromAPI(cd.Rule),
+	}
+}
+
+type CaseRule struct {
+	Operator string
+	Column   string
+	Values   []string
+	Rules    []CaseRule
+}
+
+func CaseRuleFromAPI(r *api.CaseRule) CaseRule {
+	if r == nil {
+		return CaseRule{}
+	}
+	rule := CaseRule{
+		Operator: r.Operator,
+		Column:   r.Column,
+		Values:   r.Values,
+		Rules:    make([]CaseRule, len(r.Rules)),
+	}
+	for i, sr := range r.Rules {
+		rule.Rules[i] = CaseRuleFromAPI(sr)
+	}
+	return rule
+}
+
+type DerivedColumnDef struct {
+	Name       string
+	Expression string
+}
+
+func DerivedColumnDefFromAPI(dc *api.DerivedColumn) DerivedColumnDef {
+	if dc == nil {
+		return DerivedColumnDef{}
+	}
+	return DerivedColumnDef{
+		Name:       dc.Name,
+		Expression: dc.Expression,
+	}
+}
+
+type ColumnMapping struct {
+	VersionID   string
+	QuestionKey string
+	OptionKey   string // empty if the question itself was renamed or split
+	NewKeys     []string
+}
+
+func ColumnMappingFromAPI(cm *api.ColumnMapping) ColumnMapping {
+	if cm == nil {
+		return ColumnMapping{}
+	}
+	return ColumnMapping{
+		VersionID:   cm.VersionId,
+		QuestionKey: cm.QuestionKey,
+		OptionKey:   cm.OptionKey,
+		NewKeys:     cm.NewKeys,
+	}
+}
+
+type TimestampFormat struct {
+	Format           string `json:"format"`
+	Timezone         string `json:"timezone"`
+	SeparateDateTime bool   `json:"separateDateTime"`
+	DateInputsAsDate bool   `json:"dateInputsAsDate"`
+}
+
+func TimestampFormatFromAPI(tf *api.TimestampFormat) TimestampFormat {
+	if tf == nil {
+		return TimestampFormat{}
+	}
+	return TimestampFormat{
+		Format:           tf.Format,
+		Timezone:         tf.Timezone,
+		SeparateDateTime: tf.SeparateDateTime,
+		DateInputsAsDate: tf.DateInputsAsDate,
+	}
+}
+
+type CSVDialect struct {
+	Delimiter string `json:"delimiter"`
+	QuoteAll  bool   `json:"quoteAll"`
+	BOM       bool   `json:"bom"`
+	CRLF      bool   `json:"crlf"`
+	NullValue string `json:"nullValue"`
+}
+
+func CSVDialectFromAPI(d *api.CSVDialect) CSVDialect {
+	if d == nil {
+		return CSVDialect{}
+	}
+	return CSVDialect{
+		Delimiter: d.Delimiter,
+		QuoteAll:  d.QuoteAll,
+		BOM:       d.Bom,
+		CRLF:      d.Crlf,
+		NullValue: d.NullValue,
+	}
+}
+
+type BooleanEncoding struct {
+	True  string `json:"true"`
+	False string `json:"false"`
+}
+
+func BooleanEncodingFromAPI(be *api.BooleanEncoding) BooleanEncoding {
+	if be == nil {
+		return BooleanEncoding{}
+	}
+	return BooleanEncoding{
+		True:  be.TrueValue,
+		False: be.FalseValue,
+	}
+}
+
+type ArrayEncoding struct {
+	Separator string `json:"separator"`
+	JSON      bool   `json:"json"`
+}
+
+func ArrayEncodingFromAPI(ae *api.ArrayEncoding) ArrayEncoding {
+	if ae == nil {
+		return ArrayEncoding{}
+	}
+	return ArrayEncoding{
+		Separator: ae.Separator,
+		JSON:      ae.Json,
+	}
+}
+
+type MissingValueCoding struct {
+	NotInVersion  string `json:"notInVersion"`
+	NotShown      string `json:"notShown"`
+	Skipped       string `json:"skipped"`
+	AnsweredEmpty string `json:"answeredEmpty"`
+}
+
+func MissingValueCodingFromAPI(mc *api.MissingValueCoding) *MissingValueCoding {
+	if mc == nil {
+		return nil
+	}
+	return &MissingValueCoding{
+		NotInVersion:  mc.NotInVersion,
+		NotShown:      mc.NotShown,
+		Skipped:       mc.Skipped,
+		AnsweredEmpty: mc.AnsweredEmpty,
+	}
+}
+
+type IncidenceBucket struct {
+	Label              string
+	Start              int64
+	End                int64
+	ActiveParticipants int64
+	Cases              []CaseCount
+}
+
+func (ib IncidenceBucket) ToAPI() *api.IncidenceBucket {
+	v := &api.IncidenceBucket{
+		Label:              ib.Label,
+		Start:              ib.Start,
+		End:                ib.End,
+		ActiveParticipants: ib.ActiveParticipants,
+	}
+	v.Cases = make([]*api.CaseCount, len(ib.Cases))
+	for i, c := range ib.Cases {
+		v.Cases[i] = &api.CaseCount{
+			Name:      c.Name,
+			Count:     c.Count,
+			Incidence: c.Incidence,
+		}
+	}
+	return v
+}
+
+type CaseCount struct {
+	Name      string
+	Count     int64
+	Incidence float64
+}
+
+type ActiveParticipantRules struct {
+	MinReports         int
+	ExcludeFirstReport bool
+}
+
+func ActiveParticipantRulesFromAPI(r *api.ActiveParticipantRules) ActiveParticipantRules {
+	if r == nil {
+		return ActiveParticipantRules{}
+	}
+	return ActiveParticipantRules{
+		MinReports:         int(r.MinReports),
+		ExcludeFirstReport: r.ExcludeFirstReport,
+	}
+}
+
+type ActiveParticipantsBucket struct {
+	Label              string
+	Start              int64
+	End                int64
+	ActiveParticipants int64
+	Reports            int64
+}
+
+func (ab ActiveParticipantsBucket) ToAPI() *api.ActiveParticipantsBucket {
+	return &api.ActiveParticipantsBucket{
+		Label:              ab.Label,
+		Start:              ab.Start,
+		End:                ab.End,
+		ActiveParticipants: ab.ActiveParticipants,
+		Reports:            ab.Reports,
+	}
+}
+
+type DataQualityIssue struct {
+	Type          string `json:"type"`
+	ParticipantID string `json:"participantID"`
+	Version       string `json:"version"`
+	SubmittedAt   int64  `json:"-"`
+	Submitted     string `json:"submitted"` // formatted with the timestamp format of the parser
+	QuestionKey   string `json:"questionKey,omitempty"`
+	Details       string `json:"details"`
+}
+
+type DataQualityReport struct {
+	SurveyKey     string             `json:"surveyKey"`
+	ResponseCount int                `json:"responseCount"`
+	IssueCounts   map[string]int     `json:"issueCounts"`
+	Issues        []DataQualityIssue `json:"issues"`
+}
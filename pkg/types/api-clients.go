@@ -3,10 +3,14 @@ package types
 import (
 	loggingAPI "github.com/influenzanet/logging-service/pkg/api"
 	studyAPI "github.com/influenzanet/study-service/pkg/api"
+	"google.golang.org/grpc"
 )
 
 // APIClients holds the service clients to the internal services
 type APIClients struct {
 	LoggingService loggingAPI.LoggingServiceApiClient
 	StudyService   studyAPI.StudyServiceApiClient
+	// connections of the clients, used to check the connection state
+	LoggingServiceConn *grpc.ClientConn
+	StudyServiceConn   *grpc.ClientConn
 }